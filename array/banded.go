@@ -0,0 +1,263 @@
+// banded.go
+// Solvers for tridiagonal, cyclic tridiagonal and general banded systems,
+// as arise from 1-D finite-difference and spline calculations.
+// These take O(n) operations, rather than the O(n^3) of dense elimination.
+//
+// The banded LU decomposition, with partial pivoting, follows the
+// bandec/banbks routines of Numerical Recipes.
+//
+// PJ 2026-10-18
+
+package array
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Solve the tridiagonal system with sub-diagonal a, diagonal b and
+// super-diagonal c, for right-hand side d, using the Thomas algorithm.
+// All of the vectors have length n; a[0] and c[n-1] are not used.
+// There is no pivoting, so the matrix should be diagonally dominant
+// (or otherwise known to be safe to eliminate in order).
+// The inputs are not altered and x may be the same as d.
+func (x *Vector) SolveTridiagonal(a, b, c, d *Vector, opts ...SolverOptions) (*Vector, error) {
+	return x, solveTridiagonalOf(x.Data, a.Data, b.Data, c.Data, d.Data, opts)
+}
+
+// Solve the cyclic tridiagonal system, as arises with periodic boundaries.
+// The vectors are as for SolveTridiagonal except that a[0] is now the
+// top-right corner element A[0][n-1] and c[n-1] is the bottom-left
+// corner element A[n-1][0]. The Sherman-Morrison formula is used to
+// correct the solution of a pure tridiagonal system, so n >= 3.
+func (x *Vector) SolveCyclicTridiagonal(a, b, c, d *Vector, opts ...SolverOptions) (*Vector, error) {
+	n := len(b.Data)
+	if n < 3 {
+		msg := fmt.Sprintf("Cyclic tridiagonal system too small, n=%d", n)
+		return x, errors.New(msg)
+	}
+	if len(a.Data) != n || len(c.Data) != n || len(d.Data) != n || len(x.Data) != n {
+		msg := fmt.Sprintf("Inconsistent array lengths a:%v b:%v c:%v d:%v x:%v",
+			len(a.Data), len(b.Data), len(c.Data), len(d.Data), len(x.Data))
+		return x, errors.New(msg)
+	}
+	beta := a.Data[0]
+	alpha := c.Data[n-1]
+	gamma := -b.Data[0]
+	if gamma == 0.0 {
+		gamma = -1.0
+	}
+	bb := b.Clone()
+	bb.Data[0] = b.Data[0] - gamma
+	bb.Data[n-1] = b.Data[n-1] - alpha*beta/gamma
+	_, err := x.SolveTridiagonal(a, bb, c, d, opts...)
+	if err != nil {
+		return x, err
+	}
+	u := NewVector(n)
+	u.Data[0] = gamma
+	u.Data[n-1] = alpha
+	z := NewVector(n)
+	_, err = z.SolveTridiagonal(a, bb, c, u, opts...)
+	if err != nil {
+		return x, err
+	}
+	fact := (x.Data[0] + beta*x.Data[n-1]/gamma) /
+		(1.0 + z.Data[0] + beta*z.Data[n-1]/gamma)
+	for i := 0; i < n; i++ {
+		x.Data[i] -= fact * z.Data[i]
+	}
+	return x, nil
+}
+
+// A square band matrix with KL sub-diagonals and KU super-diagonals.
+type Banded struct {
+	N, KL, KU int
+	// Row i holds the elements A[i][j] for i-KL <= j <= i+KU,
+	// with A[i][j] stored at Data[i][j-i+KL].
+	Data [][]float64
+}
+
+func NewBanded(n, kl, ku int) (*Banded, error) {
+	if n <= 0 || kl < 0 || ku < 0 {
+		msg := fmt.Sprintf("Invalid band matrix size n=%v kl=%v ku=%v", n, kl, ku)
+		return nil, errors.New(msg)
+	}
+	m, _ := NewMatrix(n, kl+ku+1)
+	return &Banded{N: n, KL: kl, KU: ku, Data: m.Data}, nil
+}
+
+func (a *Banded) inBand(i, j int) bool {
+	return i >= 0 && i < a.N && j >= 0 && j < a.N && j-i <= a.KU && i-j <= a.KL
+}
+
+// Element A[i][j], which is zero outside the band.
+func (a *Banded) At(i, j int) float64 {
+	if !a.inBand(i, j) {
+		return 0.0
+	}
+	return a.Data[i][j-i+a.KL]
+}
+
+func (a *Banded) Set(i, j int, v float64) error {
+	if !a.inBand(i, j) {
+		msg := fmt.Sprintf("Element (%d,%d) is outside the band kl=%d ku=%d", i, j, a.KL, a.KU)
+		return errors.New(msg)
+	}
+	a.Data[i][j-i+a.KL] = v
+	return nil
+}
+
+// A dense copy, mainly for checking.
+func (a *Banded) Matrix() *Matrix {
+	z, _ := NewMatrix(a.N, a.N)
+	for i := 0; i < a.N; i++ {
+		for j := max(0, i-a.KL); j <= min(a.N-1, i+a.KU); j++ {
+			z.Data[i][j] = a.At(i, j)
+		}
+	}
+	return z
+}
+
+// Matrix-vector product y = a.x, following the conventions of MulVec.
+func (y *Vector) MulBanded(a *Banded, x *Vector) (*Vector, error) {
+	if len(y.Data) != a.N || len(x.Data) != a.N {
+		msg := fmt.Sprintf("Inconsistent dimensions y:%v a:%dx%d x:%v",
+			len(y.Data), a.N, a.N, len(x.Data))
+		return y, errors.New(msg)
+	}
+	if overlaps(y.Data, x.Data) || rowsOverlap(a.Data, y.Data) {
+		return y, errors.New("Result vector must not alias an argument of MulBanded")
+	}
+	for i := 0; i < a.N; i++ {
+		s := 0.0
+		for j := max(0, i-a.KL); j <= min(a.N-1, i+a.KU); j++ {
+			s += a.Data[i][j-i+a.KL] * x.Data[j]
+		}
+		y.Data[i] = s
+	}
+	return y, nil
+}
+
+type BandedLU struct {
+	n, kl, ku int
+	// Upper factor, left-justified, with up to KL+KU super-diagonals
+	// because of the fill-in from row interchanges.
+	u [][]float64
+	// Multipliers of the lower factor.
+	l [][]float64
+	// Row interchanged with row k at step k of the elimination.
+	Perm []int
+	Sign float64
+}
+
+// Factor the band matrix a, with partial pivoting.
+// The matrix a is not altered.
+func NewBandedLU(a *Banded, opts ...SolverOptions) (*BandedLU, error) {
+	n, kl, ku := a.N, a.KL, a.KU
+	mm := kl + ku + 1
+	um, _ := NewMatrix(n, mm)
+	f := BandedLU{n: n, kl: kl, ku: ku, u: um.Data, Perm: make([]int, n), Sign: 1.0}
+	if kl > 0 {
+		lm, _ := NewMatrix(n, kl)
+		f.l = lm.Data
+	} else {
+		f.l = make([][]float64, n)
+	}
+	u := f.u
+	for i := 0; i < n; i++ {
+		copy(u[i], a.Data[i])
+	}
+	tiny := solverOptions(opts).pivotThreshold(rowSumScale(a.Data, mm))
+	// Shift the top rows left, so that every row starts with its
+	// first nonzero element, and fill the vacated space with zeros.
+	l := kl
+	for i := 0; i < kl && i < n; i++ {
+		for j := kl - i; j < mm; j++ {
+			u[i][j-l] = u[i][j]
+		}
+		l--
+		for j := mm - l - 1; j < mm; j++ {
+			u[i][j] = 0.0
+		}
+	}
+	l = kl
+	for k := 0; k < n; k++ {
+		dum := u[k][0]
+		p := k
+		if l < n {
+			l++
+		}
+		for j := k + 1; j < l; j++ {
+			if math.Abs(u[j][0]) > math.Abs(dum) {
+				dum = u[j][0]
+				p = j
+			}
+		}
+		f.Perm[k] = p
+		if math.Abs(dum) <= tiny {
+			return nil, errors.New(fmt.Sprintf("Singular with pivot=%v", dum))
+		}
+		if p != k {
+			f.Sign = -f.Sign
+			u[k], u[p] = u[p], u[k]
+		}
+		for i := k + 1; i < l; i++ {
+			dum = u[i][0] / u[k][0]
+			f.l[k][i-k-1] = dum
+			for j := 1; j < mm; j++ {
+				u[i][j-1] = u[i][j] - dum*u[k][j]
+			}
+			u[i][mm-1] = 0.0
+		}
+	}
+	return &f, nil
+}
+
+// Solve A.x = b for x, using the previously computed factors.
+// The vectors x and b may be the same.
+func (f *BandedLU) Solve(x, b *Vector) (*Vector, error) {
+	n := f.n
+	if len(x.Data) != n || len(b.Data) != n {
+		msg := fmt.Sprintf("Inconsistent array lengths n:%v x:%v b:%v",
+			n, len(x.Data), len(b.Data))
+		return x, errors.New(msg)
+	}
+	mm := f.kl + f.ku + 1
+	y := x.Data
+	copy(y, b.Data)
+	l := f.kl
+	for k := 0; k < n; k++ {
+		p := f.Perm[k]
+		if p != k {
+			y[k], y[p] = y[p], y[k]
+		}
+		if l < n {
+			l++
+		}
+		for j := k + 1; j < l; j++ {
+			y[j] -= f.l[k][j-k-1] * y[k]
+		}
+	}
+	l = 1
+	for i := n - 1; i >= 0; i-- {
+		dum := y[i]
+		for k := 1; k < l; k++ {
+			dum -= f.u[i][k] * y[k+i]
+		}
+		y[i] = dum / f.u[i][0]
+		if l < mm {
+			l++
+		}
+	}
+	return x, nil
+}
+
+func (f *BandedLU) Det() float64 {
+	det := f.Sign
+	for i := 0; i < f.n; i++ {
+		det *= f.u[i][0]
+	}
+	return det
+}
@@ -0,0 +1,121 @@
+// banded_test.go
+// Try out the tridiagonal and banded solvers.
+// PJ 2026-10-18
+//
+
+package array
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTridiagonal(t *testing.T) {
+	n := 6
+	a := NewVector(n).SetFromScalar(-1.0)
+	b := NewVector(n).SetFromScalar(2.0)
+	c := NewVector(n).SetFromScalar(-1.0)
+	// Right-hand side chosen such that x = (1, 2, ... n).
+	d := NewVector(n)
+	d.Data[n-1] = float64(n + 1)
+	x := NewVector(n)
+	_, err := x.SolveTridiagonal(a, b, c, d)
+	xref := NewVector(n)
+	for i := 0; i < n; i++ {
+		xref.Data[i] = float64(i + 1)
+	}
+	if err != nil || !x.ApproxEquals(xref, 1.0e-9) {
+		t.Errorf("Tridiagonal solve error x=%s want=%s", x.String(), xref.String())
+	}
+	_, err = x.SolveTridiagonal(a, b, c, NewVector(n-1))
+	if err == nil {
+		t.Errorf("Tridiagonal solve should have detected mismatch in lengths.")
+	}
+
+	// Periodic system, checked against the dense LU solution.
+	a.Data[0] = 0.5
+	c.Data[n-1] = -0.25
+	b.SetFromScalar(4.0)
+	d = NewVectorFromArray([]float64{1.0, 2.0, 3.0, 4.0, 5.0, 6.0})
+	_, err = x.SolveCyclicTridiagonal(a, b, c, d)
+	if err != nil {
+		t.Fatalf("Cyclic tridiagonal solve failed, err: %s", err)
+	}
+	m, _ := NewMatrix(n, n)
+	for i := 0; i < n; i++ {
+		m.Data[i][i] = b.Data[i]
+		if i > 0 {
+			m.Data[i][i-1] = a.Data[i]
+		}
+		if i < n-1 {
+			m.Data[i][i+1] = c.Data[i]
+		}
+	}
+	m.Data[0][n-1] = a.Data[0]
+	m.Data[n-1][0] = c.Data[n-1]
+	f, _ := NewLU(m)
+	xref2 := NewVector(n)
+	f.Solve(xref2, d)
+	if !x.ApproxEquals(xref2, 1.0e-9) {
+		t.Errorf("Cyclic tridiagonal solve error x=%s want=%s", x.String(), xref2.String())
+	}
+	_, err = NewVector(4).SolveCyclicTridiagonal(NewVector(2), NewVector(4), NewVector(1), NewVector(4))
+	if err == nil {
+		t.Errorf("Did not detect inconsistent lengths for cyclic tridiagonal solve.")
+	}
+}
+
+func TestBanded(t *testing.T) {
+	n := 7
+	a, err := NewBanded(n, 2, 1)
+	if err != nil {
+		t.Fatalf("Failed to construct band matrix, err: %s", err)
+	}
+	// Small diagonal elements, so that row interchanges are needed.
+	for i := 0; i < n; i++ {
+		a.Set(i, i, 0.1*float64(i+1))
+		if i+1 < n {
+			a.Set(i, i+1, 1.0)
+		}
+		if i-1 >= 0 {
+			a.Set(i, i-1, 2.0+float64(i))
+		}
+		if i-2 >= 0 {
+			a.Set(i, i-2, -1.0)
+		}
+	}
+	if a.Set(0, 3, 1.0) == nil {
+		t.Errorf("Did not detect element outside the band.")
+	}
+	if a.At(0, 3) != 0.0 || a.At(3, 1) != -1.0 {
+		t.Errorf("Incorrect band matrix elements a=%s", a.Matrix().String())
+	}
+	x := NewVectorFromArray([]float64{1.0, -2.0, 3.0, -4.0, 5.0, -6.0, 7.0})
+	b := NewVector(n)
+	_, err = b.MulBanded(a, x)
+	bref := NewVector(n)
+	bref.MulVec(a.Matrix(), x)
+	if err != nil || !b.ApproxEquals(bref, 1.0e-9) {
+		t.Errorf("Band matrix product error b=%s want=%s", b.String(), bref.String())
+	}
+	_, err = (&Vector{}).MulBanded(&Banded{}, &Vector{})
+	if err != nil {
+		t.Errorf("Band matrix product of empty operands failed, err: %s", err)
+	}
+	_, err = x.MulBanded(a, x)
+	if err == nil {
+		t.Errorf("MulBanded should have detected aliasing of result.")
+	}
+	f, err := NewBandedLU(a)
+	if err != nil {
+		t.Fatalf("Failed to factor band matrix, err: %s", err)
+	}
+	_, err = f.Solve(b, b)
+	if err != nil || !b.ApproxEquals(x, 1.0e-9) {
+		t.Errorf("Band solve error x=%s want=%s", b.String(), x.String())
+	}
+	g, _ := NewLU(a.Matrix())
+	if math.Abs(f.Det() - g.Det()) > 1.0e-9*math.Abs(g.Det()) {
+		t.Errorf("Band determinant error det=%g want=%g", f.Det(), g.Det())
+	}
+}
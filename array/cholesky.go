@@ -0,0 +1,339 @@
+// cholesky.go
+// Factorisations of symmetric matrices.
+//
+// Cholesky: A = L.L^T for symmetric positive-definite A.
+// LDL^T:    P.A.P^T = L.D.L^T for symmetric A that may be indefinite,
+//           with L unit lower-triangular, D block-diagonal with 1x1
+//           and 2x2 blocks, and P the Bunch-Kaufman symmetric pivoting.
+// Both take about half the work of LU, since they keep the symmetry.
+//
+// PJ 2026-10-18
+
+package array
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Returns true if a is square and a[i][j] matches a[j][i] within
+// the relative tolerance, in the same sense as ApproxEquals.
+func (a *Matrix) IsSymmetric(tol float64) bool {
+	n := len(a.Data)
+	if n == 0 || len(a.Data[0]) != n {
+		return false
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			aa := a.Data[i][j]
+			bb := a.Data[j][i]
+			if math.Abs(aa-bb)/(0.5*(math.Abs(aa)+math.Abs(bb)+1.0)) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Checks that a is square and symmetric, before factorisation.
+func checkSymmetric(a *Matrix) error {
+	n := len(a.Data)
+	if n == 0 {
+		return errors.New("Empty Matrix")
+	}
+	if len(a.Data[0]) != n {
+		msg := fmt.Sprintf("Matrix is not square: nrows=%d ncols=%d", n, len(a.Data[0]))
+		return errors.New(msg)
+	}
+	if !a.IsSymmetric(1.0e-12) {
+		return errors.New("Matrix is not symmetric")
+	}
+	return nil
+}
+
+type Cholesky struct {
+	L *Matrix // Lower-triangular factor.
+}
+
+// Factor the symmetric positive-definite matrix a such that A = L.L^T
+// The matrix must be symmetric, to within a relative tolerance of 1.0e-12,
+// although only its lower triangle is used in the factorisation.
+func NewCholesky(a *Matrix, opts ...SolverOptions) (*Cholesky, error) {
+	err := checkSymmetric(a)
+	if err != nil {
+		return nil, err
+	}
+	n := len(a.Data)
+	tiny := solverOptions(opts).pivotThreshold(rowSumScale(a.Data, n))
+	l, _ := NewMatrix(n, n)
+	for j := 0; j < n; j++ {
+		d := a.Data[j][j]
+		for k := 0; k < j; k++ {
+			d -= l.Data[j][k] * l.Data[j][k]
+		}
+		if d <= tiny {
+			msg := fmt.Sprintf("Not positive definite at row %d, d=%v", j, d)
+			return nil, errors.New(msg)
+		}
+		ljj := math.Sqrt(d)
+		l.Data[j][j] = ljj
+		for i := j + 1; i < n; i++ {
+			s := a.Data[i][j]
+			for k := 0; k < j; k++ {
+				s -= l.Data[i][k] * l.Data[j][k]
+			}
+			l.Data[i][j] = s / ljj
+		}
+	}
+	return &Cholesky{L: l}, nil
+}
+
+func (a *Matrix) IsPositiveDefinite(opts ...SolverOptions) bool {
+	_, err := NewCholesky(a, opts...)
+	return err == nil
+}
+
+// Solve A.x = b for x, using the previously computed factor.
+// The vectors x and b may be the same.
+func (f *Cholesky) Solve(x, b *Vector) (*Vector, error) {
+	n := len(f.L.Data)
+	if len(x.Data) != n || len(b.Data) != n {
+		msg := fmt.Sprintf("Inconsistent array lengths n:%v x:%v b:%v",
+			n, len(x.Data), len(b.Data))
+		return x, errors.New(msg)
+	}
+	l := f.L.Data
+	y := x.Data
+	copy(y, b.Data)
+	// Forward substitution with L then back substitution with L^T.
+	for i := 0; i < n; i++ {
+		s := y[i]
+		for k := 0; k < i; k++ {
+			s -= l[i][k] * y[k]
+		}
+		y[i] = s / l[i][i]
+	}
+	for i := n - 1; i >= 0; i-- {
+		s := y[i]
+		for k := i + 1; k < n; k++ {
+			s -= l[k][i] * y[k]
+		}
+		y[i] = s / l[i][i]
+	}
+	return x, nil
+}
+
+func (f *Cholesky) Det() float64 {
+	det := 1.0
+	for i := 0; i < len(f.L.Data); i++ {
+		det *= f.L.Data[i][i]
+	}
+	return det * det
+}
+
+type LDLT struct {
+	L *Matrix   // Unit lower-triangular factor.
+	D []float64 // Diagonal of the block-diagonal factor.
+	// Subdiagonal of the block-diagonal factor, with E[k] nonzero only where
+	// rows k and k+1 form a 2x2 block. E[n-1] is always zero.
+	E    []float64
+	Perm []int // Row i of P.A.P^T is row Perm[i] of A.
+}
+
+// Threshold of Bunch and Kaufman for choosing a 1x1 pivot,
+// which bounds the growth of the elements to 2.57 per step.
+var bunchKaufmanAlpha = (1.0 + math.Sqrt(17.0)) / 8.0
+
+// Factor the symmetric matrix a such that P.A.P^T = L.D.L^T
+// with the symmetric pivoting of J.R. Bunch and L. Kaufman (1977)
+// Some stable methods for calculating inertia and solving symmetric
+// linear systems. Math. Comp. 31(137):163-179.
+// D has 1x1 and 2x2 diagonal blocks, so that indefinite matrices such as
+// [[0,1],[1,0]], with no usable diagonal element, can be factored.
+// The matrix must be symmetric, to within a relative tolerance of 1.0e-12.
+func NewLDLT(a *Matrix, opts ...SolverOptions) (*LDLT, error) {
+	err := checkSymmetric(a)
+	if err != nil {
+		return nil, err
+	}
+	n := len(a.Data)
+	tiny := solverOptions(opts).pivotThreshold(rowSumScale(a.Data, n))
+	// Work on a full copy, updating the trailing Schur complement in place.
+	w := a.Clone().Data
+	l, _ := NewMatrix(n, n)
+	f := LDLT{L: l, D: make([]float64, n), E: make([]float64, n), Perm: make([]int, n)}
+	for i := 0; i < n; i++ {
+		f.Perm[i] = i
+	}
+	// Symmetric interchange of rows and columns i and j, for i < j,
+	// carrying along the rows of the columns of L already computed.
+	swap := func(i, j int) {
+		w[i], w[j] = w[j], w[i]
+		for k := 0; k < n; k++ {
+			w[k][i], w[k][j] = w[k][j], w[k][i]
+		}
+		for k := 0; k < i; k++ {
+			l.Data[i][k], l.Data[j][k] = l.Data[j][k], l.Data[i][k]
+		}
+		f.Perm[i], f.Perm[j] = f.Perm[j], f.Perm[i]
+	}
+	for k := 0; k < n; {
+		// Largest element below the diagonal in column k.
+		colmax, r := 0.0, k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(w[i][k]) > colmax {
+				colmax, r = math.Abs(w[i][k]), i
+			}
+		}
+		akk := math.Abs(w[k][k])
+		if math.Max(akk, colmax) <= tiny {
+			return nil, errors.New(fmt.Sprintf("Singular at row %d with pivot=%v", k, w[k][k]))
+		}
+		block := 1
+		if akk < bunchKaufmanAlpha*colmax {
+			// Largest off-diagonal element in row r of the trailing matrix.
+			rowmax := 0.0
+			for j := k; j < n; j++ {
+				if j != r {
+					rowmax = math.Max(rowmax, math.Abs(w[r][j]))
+				}
+			}
+			if akk*rowmax >= bunchKaufmanAlpha*colmax*colmax {
+				// The diagonal element is big enough after all.
+			} else if math.Abs(w[r][r]) >= bunchKaufmanAlpha*rowmax {
+				swap(k, r)
+			} else {
+				block = 2
+				if r != k+1 {
+					swap(k+1, r)
+				}
+			}
+		}
+		if block == 1 {
+			d := w[k][k]
+			if math.Abs(d) <= tiny {
+				return nil, errors.New(fmt.Sprintf("Singular at row %d with pivot=%v", k, d))
+			}
+			f.D[k] = d
+			l.Data[k][k] = 1.0
+			for i := k + 1; i < n; i++ {
+				l.Data[i][k] = w[i][k] / d
+			}
+			for i := k + 1; i < n; i++ {
+				for j := k + 1; j < n; j++ {
+					w[i][j] -= l.Data[i][k] * w[k][j]
+				}
+			}
+			k++
+			continue
+		}
+		p, q, c := w[k][k], w[k+1][k], w[k+1][k+1]
+		det := p*c - q*q
+		if math.Abs(det) <= tiny*math.Abs(q) {
+			return nil, errors.New(fmt.Sprintf("Singular 2x2 block at row %d with det=%v", k, det))
+		}
+		f.D[k], f.D[k+1], f.E[k] = p, c, q
+		l.Data[k][k], l.Data[k+1][k+1] = 1.0, 1.0
+		for i := k + 2; i < n; i++ {
+			// Row i of L times the 2x2 block gives row i of the trailing matrix.
+			l.Data[i][k] = (w[i][k]*c - w[i][k+1]*q) / det
+			l.Data[i][k+1] = (w[i][k+1]*p - w[i][k]*q) / det
+		}
+		for i := k + 2; i < n; i++ {
+			for j := k + 2; j < n; j++ {
+				w[i][j] -= l.Data[i][k]*w[j][k] + l.Data[i][k+1]*w[j][k+1]
+			}
+		}
+		k += 2
+	}
+	return &f, nil
+}
+
+// Solve A.x = b for x, using the previously computed factors.
+// The vectors x and b may be the same.
+func (f *LDLT) Solve(x, b *Vector) (*Vector, error) {
+	n := len(f.D)
+	if len(x.Data) != n || len(b.Data) != n {
+		msg := fmt.Sprintf("Inconsistent array lengths n:%v x:%v b:%v",
+			n, len(x.Data), len(b.Data))
+		return x, errors.New(msg)
+	}
+	l := f.L.Data
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		y[i] = b.Data[f.Perm[i]]
+	}
+	for i := 0; i < n; i++ {
+		s := y[i]
+		for k := 0; k < i; k++ {
+			s -= l[i][k] * y[k]
+		}
+		y[i] = s
+	}
+	for k := 0; k < n; k++ {
+		if f.E[k] == 0.0 {
+			y[k] /= f.D[k]
+			continue
+		}
+		p, q, c := f.D[k], f.E[k], f.D[k+1]
+		det := p*c - q*q
+		y[k], y[k+1] = (c*y[k]-q*y[k+1])/det, (p*y[k+1]-q*y[k])/det
+		k++
+	}
+	for i := n - 1; i >= 0; i-- {
+		s := y[i]
+		for k := i + 1; k < n; k++ {
+			s -= l[k][i] * y[k]
+		}
+		y[i] = s
+	}
+	for i := 0; i < n; i++ {
+		x.Data[f.Perm[i]] = y[i]
+	}
+	return x, nil
+}
+
+// The symmetric interchanges do not change the determinant.
+func (f *LDLT) Det() float64 {
+	det := 1.0
+	n := len(f.D)
+	for k := 0; k < n; k++ {
+		if f.E[k] == 0.0 {
+			det *= f.D[k]
+			continue
+		}
+		det *= f.D[k]*f.D[k+1] - f.E[k]*f.E[k]
+		k++
+	}
+	return det
+}
+
+// Numbers of positive and negative eigenvalues of A,
+// from Sylvester's law of inertia.
+// A 2x2 block with negative determinant has one eigenvalue of each sign;
+// otherwise both have the sign of its diagonal.
+func (f *LDLT) Inertia() (int, int) {
+	npos, nneg := 0, 0
+	n := len(f.D)
+	for k := 0; k < n; k++ {
+		if f.E[k] == 0.0 {
+			if f.D[k] > 0.0 {
+				npos++
+			} else {
+				nneg++
+			}
+			continue
+		}
+		if f.D[k]*f.D[k+1]-f.E[k]*f.E[k] < 0.0 {
+			npos++
+			nneg++
+		} else if f.D[k] > 0.0 {
+			npos += 2
+		} else {
+			nneg += 2
+		}
+		k++
+	}
+	return npos, nneg
+}
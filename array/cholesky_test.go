@@ -0,0 +1,119 @@
+// cholesky_test.go
+// Try out the factorisations of symmetric matrices.
+// PJ 2026-10-18
+//
+
+package array
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCholesky(t *testing.T) {
+	a, _ := NewMatrixFromArray([][]float64{{4.0, 12.0, -16.0},
+		{12.0, 37.0, -43.0},
+		{-16.0, -43.0, 98.0}})
+	if !a.IsSymmetric(1.0e-12) {
+		t.Errorf("Symmetric matrix not recognised a=%s", a.String())
+	}
+	if !a.IsPositiveDefinite() {
+		t.Errorf("Positive-definite matrix not recognised a=%s", a.String())
+	}
+	f, err := NewCholesky(a)
+	if err != nil {
+		t.Fatalf("Failed to factor a, err: %s", err)
+	}
+	lref, _ := NewMatrixFromArray([][]float64{{2.0, 0.0, 0.0}, {6.0, 1.0, 0.0}, {-8.0, 5.0, 3.0}})
+	if !f.L.ApproxEquals(lref, 1.0e-9) {
+		t.Errorf("Incorrect Cholesky factor L=%s want=%s", f.L.String(), lref.String())
+	}
+	// Row sums, so that the solution is all ones.
+	b := NewVectorFromArray([]float64{0.0, 6.0, 39.0})
+	x := NewVector(3)
+	_, err = f.Solve(x, b)
+	xref := NewVectorFromArray([]float64{1.0, 1.0, 1.0})
+	if err != nil || !x.ApproxEquals(xref, 1.0e-9) {
+		t.Errorf("Cholesky solve error x=%s want=%s", x.String(), xref.String())
+	}
+	if math.Abs(f.Det() - 36.0) > 1.0e-9 {
+		t.Errorf("Cholesky determinant error det=%g want=36.0", f.Det())
+	}
+
+	// Symmetric but indefinite.
+	c, _ := NewMatrixFromArray([][]float64{{1.0, 2.0, 3.0}, {2.0, -1.0, 4.0}, {3.0, 4.0, 2.0}})
+	if c.IsPositiveDefinite() {
+		t.Errorf("Indefinite matrix reported as positive definite c=%s", c.String())
+	}
+	g, err := NewLDLT(c)
+	if err != nil {
+		t.Fatalf("Failed to factor c, err: %s", err)
+	}
+	b2 := NewVectorFromArray([]float64{6.0, 5.0, 9.0})
+	_, err = g.Solve(b2, b2)
+	if err != nil || !b2.ApproxEquals(xref, 1.0e-9) {
+		t.Errorf("LDLT solve error x=%s want=%s", b2.String(), xref.String())
+	}
+	if math.Abs(g.Det() - 31.0) > 1.0e-9 {
+		t.Errorf("LDLT determinant error det=%g want=31.0", g.Det())
+	}
+	npos, nneg := g.Inertia()
+	if npos != 1 || nneg != 2 {
+		t.Errorf("LDLT inertia error npos=%d nneg=%d want 1 and 2", npos, nneg)
+	}
+
+	// No usable diagonal element, so a 2x2 pivot block is needed.
+	e, _ := NewMatrixFromArray([][]float64{{0.0, 1.0}, {1.0, 0.0}})
+	h, err := NewLDLT(e)
+	if err != nil {
+		t.Fatalf("Failed to factor e, err: %s", err)
+	}
+	b3 := NewVectorFromArray([]float64{2.0, 3.0})
+	xref3 := NewVectorFromArray([]float64{3.0, 2.0})
+	_, err = h.Solve(b3, b3)
+	if err != nil || !b3.ApproxEquals(xref3, 1.0e-12) {
+		t.Errorf("LDLT 2x2 block solve error x=%s want=%s", b3.String(), xref3.String())
+	}
+	if math.Abs(h.Det()+1.0) > 1.0e-12 {
+		t.Errorf("LDLT 2x2 block determinant error det=%g want=-1.0", h.Det())
+	}
+	npos, nneg = h.Inertia()
+	if npos != 1 || nneg != 1 {
+		t.Errorf("LDLT 2x2 block inertia error npos=%d nneg=%d want 1 and 1", npos, nneg)
+	}
+
+	// A tiny leading diagonal element must be passed over, to avoid growth.
+	s, _ := NewMatrixFromArray([][]float64{{1.0e-10, 1.0}, {1.0, 1.0}})
+	h, err = NewLDLT(s)
+	if err != nil {
+		t.Fatalf("Failed to factor s, err: %s", err)
+	}
+	for i, d := range h.D {
+		if math.Abs(d) > 2.0 {
+			t.Errorf("LDLT growth error D[%d]=%g", i, d)
+		}
+	}
+	for i := range h.L.Data {
+		for j, lij := range h.L.Data[i] {
+			if math.Abs(lij) > 1.0 {
+				t.Errorf("LDLT growth error L[%d][%d]=%g", i, j, lij)
+			}
+		}
+	}
+	b4 := NewVectorFromArray([]float64{1.0, 2.0})
+	x4 := NewVectorFromArray([]float64{0.0, 0.0})
+	_, err = h.Solve(x4, b4)
+	r4 := NewVectorFromArray([]float64{1.0e-10*x4.Data[0] + x4.Data[1], x4.Data[0] + x4.Data[1]})
+	if err != nil || !r4.ApproxEquals(b4, 1.0e-12) {
+		t.Errorf("LDLT pivoted solve error residual=%s want=%s", r4.String(), b4.String())
+	}
+
+	u, _ := NewMatrixFromArray([][]float64{{1.0, 2.0}, {3.0, 4.0}})
+	if u.IsSymmetric(1.0e-12) {
+		t.Errorf("Unsymmetric matrix reported as symmetric u=%s", u.String())
+	}
+	_, err = NewCholesky(u)
+	if err == nil {
+		t.Errorf("Did not detect unsymmetric matrix u=%s", u.String())
+	}
+}
@@ -0,0 +1,276 @@
+// dense.go
+// A dense matrix with a single, contiguous, row-major backing store.
+//
+// Element (i,j) lives at Data[i*Stride+j], so rows are contiguous and
+// columns are strided views into the same storage. Submatrices made by
+// Slice share storage with their parent, with the parent's stride.
+// NewMatrix and Clone also give a Matrix one backing store, but its
+// rows may be reordered or replaced, so code working on a Matrix can
+// not rely on the layout. Dense fixes the layout, and so allows
+// submatrix and column views without copying.
+// The Matrix adapter gives the existing Data[i][j] access path,
+// and all of the Matrix factorisations, without copying.
+//
+// PJ 2026-10-18
+
+package array
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"unsafe"
+)
+
+type Dense struct {
+	Rows, Cols int
+	Stride     int
+	Data       []float64
+}
+
+func NewDense(nrows, ncols int) (*Dense, error) {
+	if nrows <= 0 {
+		msg := fmt.Sprintf("Invalid value for nrows=%v", nrows)
+		return nil, errors.New(msg)
+	}
+	if ncols <= 0 {
+		msg := fmt.Sprintf("Invalid value for ncols=%v", ncols)
+		return nil, errors.New(msg)
+	}
+	d := Dense{Rows: nrows, Cols: ncols, Stride: ncols,
+		Data: make([]float64, nrows*ncols)}
+	return &d, nil
+}
+
+func NewDenseFromArray(data [][]float64) (*Dense, error) {
+	nrows := len(data)
+	if nrows == 0 {
+		return nil, errors.New("Zero rows")
+	}
+	ncols0 := len(data[0])
+	if ncols0 == 0 {
+		return nil, errors.New("Zero columns")
+	}
+	for i := 0; i < nrows; i++ {
+		if len(data[i]) != ncols0 {
+			msg := fmt.Sprintf("Ragged rows: ncols0=%d ncols[%d]=%d", ncols0, i, len(data[i]))
+			return nil, errors.New(msg)
+		}
+	}
+	d, _ := NewDense(nrows, ncols0)
+	for i := 0; i < nrows; i++ {
+		copy(d.Data[i*d.Stride:i*d.Stride+ncols0], data[i])
+	}
+	return d, nil
+}
+
+// Copy the elements of a Matrix into new contiguous storage.
+func NewDenseFromMatrix(a *Matrix) (*Dense, error) {
+	return NewDenseFromArray(a.Data)
+}
+
+// A Matrix whose rows are slices of the storage of d, so that
+// writes through either are seen by both.
+// Row interchanges within the Matrix, as done by GaussJordanElimination,
+// reorder only the row slices of the Matrix and are not seen by d.
+func (d *Dense) Matrix() *Matrix {
+	z := Matrix{Data: make([][]float64, d.Rows)}
+	for i := 0; i < d.Rows; i++ {
+		start := i * d.Stride
+		z.Data[i] = d.Data[start : start+d.Cols : start+d.Cols]
+	}
+	return &z
+}
+
+func (d *Dense) IsEmpty() bool {
+	return d.Rows == 0 || d.Cols == 0
+}
+
+func (d *Dense) Dims() (int, int) {
+	return d.Rows, d.Cols
+}
+
+func (d *Dense) At(i, j int) float64 {
+	return d.Data[i*d.Stride+j]
+}
+
+func (d *Dense) Set(i, j int, v float64) {
+	d.Data[i*d.Stride+j] = v
+}
+
+// A view of N elements starting at Data[0] and separated by Inc.
+type Strided struct {
+	Data []float64
+	N    int
+	Inc  int
+}
+
+func (s Strided) At(i int) float64 {
+	return s.Data[i*s.Inc]
+}
+
+func (s Strided) Set(i int, v float64) {
+	s.Data[i*s.Inc] = v
+}
+
+// Copy the viewed elements into a new Vector.
+func (s Strided) Vector() *Vector {
+	z := NewVector(s.N)
+	for i := 0; i < s.N; i++ {
+		z.Data[i] = s.Data[i*s.Inc]
+	}
+	return z
+}
+
+// Contiguous view of row i.
+func (d *Dense) RowView(i int) Strided {
+	start := i * d.Stride
+	return Strided{Data: d.Data[start : start+d.Cols], N: d.Cols, Inc: 1}
+}
+
+// Strided view of column j.
+func (d *Dense) ColView(j int) Strided {
+	end := (d.Rows-1)*d.Stride + j + 1
+	return Strided{Data: d.Data[j:end], N: d.Rows, Inc: d.Stride}
+}
+
+// The submatrix of rows i0 <= i < i1 and columns j0 <= j < j1,
+// sharing storage with d.
+func (d *Dense) Slice(i0, i1, j0, j1 int) (*Dense, error) {
+	if i0 < 0 || i1 > d.Rows || i0 >= i1 || j0 < 0 || j1 > d.Cols || j0 >= j1 {
+		msg := fmt.Sprintf("Invalid slice rows %d:%d cols %d:%d of %dx%d",
+			i0, i1, j0, j1, d.Rows, d.Cols)
+		return nil, errors.New(msg)
+	}
+	start := i0*d.Stride + j0
+	end := (i1-1)*d.Stride + j1
+	z := Dense{Rows: i1 - i0, Cols: j1 - j0, Stride: d.Stride, Data: d.Data[start:end]}
+	return &z, nil
+}
+
+// A copy with its own compact storage.
+func (d *Dense) Clone() *Dense {
+	z := Dense{Rows: d.Rows, Cols: d.Cols, Stride: d.Cols,
+		Data: make([]float64, d.Rows*d.Cols)}
+	for i := 0; i < d.Rows; i++ {
+		copy(z.Data[i*z.Stride:(i+1)*z.Stride], d.Data[i*d.Stride:i*d.Stride+d.Cols])
+	}
+	return &z
+}
+
+func (d *Dense) String() string {
+	var b bytes.Buffer
+	b.WriteString("[")
+	for i := 0; i < d.Rows; i++ {
+		b.WriteString("[")
+		for j := 0; j < d.Cols; j++ {
+			b.WriteString(fmt.Sprintf("%g", d.At(i, j)))
+			if j+1 < d.Cols {
+				b.WriteString(", ")
+			}
+		}
+		b.WriteString("]")
+		if i+1 < d.Rows {
+			b.WriteString(", ")
+		}
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+func (d *Dense) ApproxEquals(other *Dense, tol float64) bool {
+	if d.Rows != other.Rows || d.Cols != other.Cols {
+		return false
+	}
+	for i := 0; i < d.Rows; i++ {
+		for j := 0; j < d.Cols; j++ {
+			aa := d.At(i, j)
+			bb := other.At(i, j)
+			// Relative comparison for large numbers, absolute comparison for small numbers
+			if math.Abs(aa-bb)/(0.5*(math.Abs(aa)+math.Abs(bb)+1.0)) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// True if the memory spanned by the elements of a and b overlaps.
+// Interleaved strided views count as overlapping, which is conservative.
+func overlaps[T any](a, b []T) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	size := unsafe.Sizeof(a[0])
+	a0 := uintptr(unsafe.Pointer(&a[0]))
+	b0 := uintptr(unsafe.Pointer(&b[0]))
+	return a0 < b0+uintptr(len(b))*size && b0 < a0+uintptr(len(a))*size
+}
+
+// True if any of the rows overlaps the memory of v.
+func rowsOverlap[T any](rows [][]T, v []T) bool {
+	for _, r := range rows {
+		if overlaps(r, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matrix product z = a.b, following the conventions of Matrix.Mul.
+// The receiver must not share storage with either argument.
+func (z *Dense) Mul(a, b *Dense) (*Dense, error) {
+	if z.Rows != a.Rows || z.Cols != b.Cols || a.Cols != b.Rows {
+		msg := fmt.Sprintf("Inconsistent matrix dimensions z:%dx%d a:%dx%d b:%dx%d",
+			z.Rows, z.Cols, a.Rows, a.Cols, b.Rows, b.Cols)
+		return z, errors.New(msg)
+	}
+	if a.IsEmpty() || b.IsEmpty() {
+		return z, errors.New("Empty Dense matrix")
+	}
+	if overlaps(z.Data, a.Data) || overlaps(z.Data, b.Data) {
+		return z, errors.New("Result matrix must not alias an argument of Mul")
+	}
+	for i := 0; i < z.Rows; i++ {
+		zi := z.Data[i*z.Stride : i*z.Stride+z.Cols]
+		for j := range zi {
+			zi[j] = 0.0
+		}
+		for k := 0; k < a.Cols; k++ {
+			aik := a.Data[i*a.Stride+k]
+			if aik == 0.0 {
+				continue
+			}
+			bk := b.Data[k*b.Stride : k*b.Stride+b.Cols]
+			for j, bkj := range bk {
+				zi[j] += aik * bkj
+			}
+		}
+	}
+	return z, nil
+}
+
+// Matrix-vector product y = a.x, following the conventions of MulVec.
+func (y *Vector) MulDenseVec(a *Dense, x *Vector) (*Vector, error) {
+	if len(y.Data) != a.Rows || len(x.Data) != a.Cols {
+		msg := fmt.Sprintf("Inconsistent dimensions y:%v a:%dx%d x:%v",
+			len(y.Data), a.Rows, a.Cols, len(x.Data))
+		return y, errors.New(msg)
+	}
+	if a.IsEmpty() {
+		return y, errors.New("Empty Dense matrix")
+	}
+	if overlaps(y.Data, x.Data) || overlaps(y.Data, a.Data) {
+		return y, errors.New("Result vector must not alias an argument of MulDenseVec")
+	}
+	for i := 0; i < a.Rows; i++ {
+		ai := a.Data[i*a.Stride : i*a.Stride+a.Cols]
+		s := 0.0
+		for j, aij := range ai {
+			s += aij * x.Data[j]
+		}
+		y.Data[i] = s
+	}
+	return y, nil
+}
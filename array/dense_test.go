@@ -0,0 +1,141 @@
+// dense_test.go
+// Try out the Dense matrix with contiguous storage.
+// PJ 2026-10-18
+//
+
+package array
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDense(t *testing.T) {
+	d, err := NewDenseFromArray([][]float64{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}, {7.0, 8.0, 10.0}})
+	if err != nil {
+		t.Fatalf("Did not construct dense matrix, err: %s", err)
+	}
+	if d.At(1, 2) != 6.0 || len(d.Data) != 9 {
+		t.Errorf("Incorrect storage for d=%s", d.String())
+	}
+	col := d.ColView(1).Vector()
+	colref := NewVectorFromArray([]float64{2.0, 5.0, 8.0})
+	if !col.ApproxEquals(colref, 1.0e-9) {
+		t.Errorf("Incorrect column view=%s want=%s", col.String(), colref.String())
+	}
+	d.RowView(0).Set(0, 11.0)
+	if d.At(0, 0) != 11.0 {
+		t.Errorf("Row view does not share storage, d=%s", d.String())
+	}
+	d.Set(0, 0, 1.0)
+	// The lower-right 2x2 block shares storage with d.
+	s, err := d.Slice(1, 3, 1, 3)
+	if err != nil {
+		t.Fatalf("Failed to slice d, err: %s", err)
+	}
+	sref, _ := NewDenseFromArray([][]float64{{5.0, 6.0}, {8.0, 10.0}})
+	if !s.ApproxEquals(sref, 1.0e-9) || s.Stride != 3 {
+		t.Errorf("Incorrect slice=%s want=%s", s.String(), sref.String())
+	}
+	if s.ColView(1).At(1) != 10.0 {
+		t.Errorf("Incorrect column view of slice=%s", s.String())
+	}
+	s.Set(0, 0, 0.0)
+	if d.At(1, 1) != 0.0 {
+		t.Errorf("Slice does not share storage, d=%s", d.String())
+	}
+	s.Set(0, 0, 5.0)
+	_, err = d.Slice(2, 1, 0, 3)
+	if err == nil {
+		t.Errorf("Did not detect invalid slice.")
+	}
+	c := s.Clone()
+	if c.Stride != 2 || len(c.Data) != 4 || !c.ApproxEquals(s, 1.0e-9) {
+		t.Errorf("Incorrect clone=%s want=%s", c.String(), s.String())
+	}
+	// The Matrix adapter shares storage and gives us the factorisations.
+	m := d.Matrix()
+	m.Data[2][2] = 9.0
+	if d.At(2, 2) != 9.0 {
+		t.Errorf("Matrix adapter does not share storage, d=%s", d.String())
+	}
+	m.Data[2][2] = 10.0
+	f, err := NewLU(m)
+	if err != nil || math.Abs(f.Det() + 3.0) > 1.0e-9 {
+		t.Errorf("LU via adapter failed for m=%s", m.String())
+	}
+	e, _ := NewDense(3, 3)
+	b, _ := NewDenseFromMatrix(m)
+	_, err = e.Mul(d, b)
+	eref, _ := NewMatrix(3, 3)
+	eref.Mul(m, m)
+	if err != nil || !e.Matrix().ApproxEquals(eref, 1.0e-9) {
+		t.Errorf("Dense Mul error e=%s want=%s", e.String(), eref.String())
+	}
+	x := NewVectorFromArray([]float64{1.0, 1.0, 1.0})
+	y := NewVector(3)
+	_, err = y.MulDenseVec(d, x)
+	yref := NewVectorFromArray([]float64{6.0, 15.0, 25.0})
+	if err != nil || !y.ApproxEquals(yref, 1.0e-9) {
+		t.Errorf("Dense MulVec error y=%s want=%s", y.String(), yref.String())
+	}
+	// A result that overlaps an argument, other than at its start, is refused.
+	big, _ := NewDense(4, 4)
+	for i := range big.Data {
+		big.Data[i] = float64(i)
+	}
+	left, _ := big.Slice(0, 2, 0, 2)
+	inner, _ := big.Slice(1, 3, 1, 3)
+	_, err = inner.Mul(left, left)
+	if err == nil {
+		t.Errorf("Did not detect overlapping result for Mul.")
+	}
+	w := NewVector(4)
+	_, err = (&Vector{Data: w.Data[0:3]}).MulDenseVec(d, &Vector{Data: w.Data[1:4]})
+	if err == nil {
+		t.Errorf("Did not detect overlapping result for MulDenseVec.")
+	}
+	// Zero-value matrices give an error rather than a panic.
+	var empty Dense
+	_, err = empty.Mul(&Dense{}, &Dense{})
+	if err == nil {
+		t.Errorf("Did not detect empty matrices for Mul.")
+	}
+	_, err = (&Vector{}).MulDenseVec(&Dense{}, &Vector{})
+	if err == nil {
+		t.Errorf("Did not detect empty matrix for MulDenseVec.")
+	}
+}
+
+// Compare the matrix product for the two storage schemes, with
+// go test -bench=Mul ./array
+const benchSize = 200
+
+func benchData(n int) [][]float64 {
+	data := make([][]float64, n)
+	for i := range data {
+		data[i] = make([]float64, n)
+		for j := range data[i] {
+			data[i][j] = float64((i*n+j)%7) - 3.0
+		}
+	}
+	return data
+}
+
+func BenchmarkMulMatrix(b *testing.B) {
+	a, _ := NewMatrixFromArray(benchData(benchSize))
+	c := a.Clone()
+	z, _ := NewMatrix(benchSize, benchSize)
+	for b.Loop() {
+		z.Mul(a, c)
+	}
+}
+
+func BenchmarkMulDense(b *testing.B) {
+	a, _ := NewDenseFromArray(benchData(benchSize))
+	c := a.Clone()
+	z, _ := NewDense(benchSize, benchSize)
+	for b.Loop() {
+		z.Mul(a, c)
+	}
+}
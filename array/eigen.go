@@ -0,0 +1,113 @@
+// eigen.go
+// Eigenvalues and eigenvectors of a real symmetric Matrix
+// by the cyclic Jacobi method.
+//
+// Each sweep applies plane rotations to annihilate the off-diagonal
+// elements in turn. Convergence is quadratic once the off-diagonal
+// elements are small, so a handful of sweeps suffice for the small
+// matrices (inertia tensors, covariance matrices) intended here.
+//
+// PJ 2026-10-18
+
+package array
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+type SymEigen struct {
+	Values  *Vector // Eigenvalues in ascending order.
+	Vectors *Matrix // Orthonormal eigenvectors, as columns, in the same order.
+}
+
+const maxJacobiSweeps = 100
+
+// Decompose the symmetric matrix a such that A = V.diag(values).V^T
+// The matrix a is not altered.
+func NewSymEigen(a *Matrix) (*SymEigen, error) {
+	err := checkSymmetric(a)
+	if err != nil {
+		return nil, err
+	}
+	n := len(a.Data)
+	w, _ := NewMatrixFromArray(a.Data)
+	c := w.Data
+	v, _ := NewMatrix(n, n)
+	for i := 0; i < n; i++ {
+		v.Data[i][i] = 1.0
+	}
+	scale := 0.0
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			scale += c[i][j] * c[i][j]
+		}
+	}
+	converged := false
+	for sweep := 0; sweep < maxJacobiSweeps; sweep++ {
+		off := 0.0
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				off += c[i][j] * c[i][j]
+			}
+		}
+		if off <= machineEpsilon*machineEpsilon*scale {
+			converged = true
+			break
+		}
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				if c[p][q] == 0.0 {
+					continue
+				}
+				// Rotation angle chosen to zero c[p][q], taking the
+				// smaller root for tan(phi) for stability.
+				theta := (c[q][q] - c[p][p]) / (2.0 * c[p][q])
+				tn := 1.0 / (math.Abs(theta) + math.Sqrt(theta*theta+1.0))
+				if theta < 0.0 {
+					tn = -tn
+				}
+				cs := 1.0 / math.Sqrt(tn*tn+1.0)
+				sn := tn * cs
+				for k := 0; k < n; k++ {
+					ckp, ckq := c[k][p], c[k][q]
+					c[k][p] = cs*ckp - sn*ckq
+					c[k][q] = sn*ckp + cs*ckq
+				}
+				for k := 0; k < n; k++ {
+					cpk, cqk := c[p][k], c[q][k]
+					c[p][k] = cs*cpk - sn*cqk
+					c[q][k] = sn*cpk + cs*cqk
+				}
+				for k := 0; k < n; k++ {
+					vkp, vkq := v.Data[k][p], v.Data[k][q]
+					v.Data[k][p] = cs*vkp - sn*vkq
+					v.Data[k][q] = sn*vkp + cs*vkq
+				}
+			}
+		}
+	}
+	if !converged {
+		msg := fmt.Sprintf("Jacobi iteration did not converge in %d sweeps", maxJacobiSweeps)
+		return nil, errors.New(msg)
+	}
+	// Sort the eigenpairs by ascending eigenvalue.
+	idx := make([]int, n)
+	for i := 0; i < n; i++ {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i int, j int) bool {
+		return c[idx[i]][idx[i]] < c[idx[j]][idx[j]]
+	})
+	e := SymEigen{Values: NewVector(n)}
+	e.Vectors, _ = NewMatrix(n, n)
+	for j := 0; j < n; j++ {
+		e.Values.Data[j] = c[idx[j]][idx[j]]
+		for i := 0; i < n; i++ {
+			e.Vectors.Data[i][j] = v.Data[i][idx[j]]
+		}
+	}
+	return &e, nil
+}
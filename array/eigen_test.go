@@ -0,0 +1,68 @@
+// eigen_test.go
+// Try out the symmetric eigenvalue decomposition.
+// PJ 2026-10-18
+//
+
+package array
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSymEigen(t *testing.T) {
+	a, _ := NewMatrixFromArray([][]float64{{2.0, -1.0, 0.0},
+		{-1.0, 2.0, -1.0},
+		{0.0, -1.0, 2.0}})
+	e, err := NewSymEigen(a)
+	if err != nil {
+		t.Fatalf("Failed to decompose a, err: %s", err)
+	}
+	r2 := math.Sqrt(2.0)
+	vref := NewVectorFromArray([]float64{2.0 - r2, 2.0, 2.0 + r2})
+	if !e.Values.ApproxEquals(vref, 1.0e-9) {
+		t.Errorf("Incorrect eigenvalues=%s want=%s", e.Values.String(), vref.String())
+	}
+	// Check orthonormality and that A.v = lambda.v for each pair.
+	for j := 0; j < 3; j++ {
+		for k := 0; k < 3; k++ {
+			s := 0.0
+			for i := 0; i < 3; i++ {
+				s += e.Vectors.Data[i][j] * e.Vectors.Data[i][k]
+			}
+			want := 0.0
+			if j == k {
+				want = 1.0
+			}
+			if math.Abs(s - want) > 1.0e-9 {
+				t.Errorf("Eigenvectors %d and %d not orthonormal, dot=%g", j, k, s)
+			}
+		}
+		for i := 0; i < 3; i++ {
+			av := 0.0
+			for k := 0; k < 3; k++ {
+				av += a.Data[i][k] * e.Vectors.Data[k][j]
+			}
+			if math.Abs(av - e.Values.Data[j]*e.Vectors.Data[i][j]) > 1.0e-9 {
+				t.Errorf("Eigenpair %d does not satisfy A.v = lambda.v", j)
+			}
+		}
+	}
+
+	// Already diagonal, with a repeated eigenvalue.
+	d, _ := NewMatrixFromArray([][]float64{{3.0, 0.0, 0.0}, {0.0, 1.0, 0.0}, {0.0, 0.0, 3.0}})
+	e2, err := NewSymEigen(d)
+	vref2 := NewVectorFromArray([]float64{1.0, 3.0, 3.0})
+	if err != nil || !e2.Values.ApproxEquals(vref2, 1.0e-9) {
+		t.Errorf("Incorrect eigenvalues=%s want=%s", e2.Values.String(), vref2.String())
+	}
+	if math.Abs(math.Abs(e2.Vectors.Data[1][0]) - 1.0) > 1.0e-9 {
+		t.Errorf("Incorrect eigenvector for smallest eigenvalue, V=%s", e2.Vectors.String())
+	}
+
+	u, _ := NewMatrixFromArray([][]float64{{1.0, 2.0}, {3.0, 4.0}})
+	_, err = NewSymEigen(u)
+	if err == nil {
+		t.Errorf("Did not detect unsymmetric matrix u=%s", u.String())
+	}
+}
@@ -0,0 +1,132 @@
+// expm_test.go
+// Try out the matrix power and exponential functions.
+// PJ 2026-10-18
+//
+
+package array
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMatrixPow(t *testing.T) {
+	fib, _ := NewMatrixFromArray([][]float64{{1.0, 1.0}, {1.0, 0.0}})
+	z, _ := NewMatrix(2, 2)
+	_, err := z.Pow(fib, 10)
+	zref, _ := NewMatrixFromArray([][]float64{{89.0, 55.0}, {55.0, 34.0}})
+	if err != nil || !z.ApproxEquals(zref, 1.0e-12) {
+		t.Errorf("Matrix power error z=%s want=%s", z.String(), zref.String())
+	}
+	z.Pow(fib, 0)
+	eye, _ := NewIdentityMatrix(2)
+	if !z.ApproxEquals(eye, 1.0e-12) {
+		t.Errorf("Zero power error z=%s", z.String())
+	}
+	// A^-3 . A^3 = I, with the receiver aliasing the argument.
+	zi, _ := NewMatrix(2, 2)
+	zi.SetFromMatrix(fib)
+	zi.Pow(zi, -3)
+	z.Pow(fib, 3)
+	p, _ := NewMatrix(2, 2)
+	p.Mul(zi, z)
+	if !p.ApproxEquals(eye, 1.0e-12) {
+		t.Errorf("Negative power error, A^-3.A^3=%s", p.String())
+	}
+}
+
+func TestExpm(t *testing.T) {
+	// Rotation generator.
+	theta := 0.3
+	a, _ := NewMatrixFromArray([][]float64{{0.0, theta}, {-theta, 0.0}})
+	e, _ := NewMatrix(2, 2)
+	_, err := e.Expm(a)
+	c, s := math.Cos(theta), math.Sin(theta)
+	eref, _ := NewMatrixFromArray([][]float64{{c, s}, {-s, c}})
+	if err != nil || !e.ApproxEquals(eref, 1.0e-14) {
+		t.Errorf("Matrix exponential error e=%s want=%s", e.String(), eref.String())
+	}
+	// Large norm, needing scaling and squaring.
+	d, _ := NewMatrixFromArray([][]float64{{-20.0, 0.0}, {0.0, 3.0}})
+	e.Expm(d)
+	dref, _ := NewMatrixFromArray([][]float64{{math.Exp(-20.0), 0.0}, {0.0, math.Exp(3.0)}})
+	if !e.ApproxEquals(dref, 1.0e-12) {
+		t.Errorf("Matrix exponential error e=%s want=%s", e.String(), dref.String())
+	}
+
+	// Test system 1 from the rkf45 tests, with the constant forcing
+	// folded in as an extra state that stays at 1.
+	f, _ := NewMatrixFromArray([][]float64{{-8.0/3.0, -4.0/3.0, 1.0, 12.0},
+		{-17.0/3.0, -4.0/3.0, 1.0, 29.0},
+		{-35.0/3.0, 14.0/3.0, -2.0, 48.0},
+		{0.0, 0.0, 0.0, 0.0}})
+	tf := 1.0
+	ft := f.Clone().Scale(tf)
+	prop, _ := NewMatrix(4, 4)
+	prop.Expm(ft)
+	y0 := NewVectorFromArray([]float64{0.0, 0.0, 0.0, 1.0})
+	y1 := NewVector(4)
+	y1.MulVec(prop, y0)
+	e3 := math.Exp(-3.0*tf) / 6.0
+	yref := NewVectorFromArray([]float64{
+		e3 * (6.0 - 50.0*math.Exp(tf) + 10.0*math.Exp(2.0*tf) + 34.0*math.Exp(3.0*tf)),
+		e3 * (12.0 - 125.0*math.Exp(tf) + 40.0*math.Exp(2.0*tf) + 73.0*math.Exp(3.0*tf)),
+		e3 * (14.0 - 200.0*math.Exp(tf) + 70.0*math.Exp(2.0*tf) + 116.0*math.Exp(3.0*tf)),
+		1.0})
+	if !y1.ApproxEquals(yref, 1.0e-12) {
+		t.Errorf("Propagator error y1=%s want=%s", y1.String(), yref.String())
+	}
+	y2 := NewVector(4)
+	_, err = y2.ExpmMulVec(f, tf, y0)
+	if err != nil || !y2.ApproxEquals(yref, 1.0e-12) {
+		t.Errorf("Action of exponential error y2=%s want=%s", y2.String(), yref.String())
+	}
+	_, err = y2.ExpmMulVec(f, tf, NewVector(3))
+	if err == nil {
+		t.Errorf("ExpmMulVec should have detected mismatch in dimensions.")
+	}
+
+	// Diffusion on a line, small enough in norm for the Taylor series.
+	nd := 50
+	g, _ := NewMatrix(nd, nd)
+	for i := 0; i < nd; i++ {
+		g.Data[i][i] = -2.0
+		if i > 0 {
+			g.Data[i][i-1] = 1.0
+		}
+		if i < nd-1 {
+			g.Data[i][i+1] = 1.0
+		}
+	}
+	u0 := NewVector(nd)
+	for i := 0; i < nd; i++ {
+		u0.Data[i] = math.Sin(float64(i) * 0.1)
+	}
+	gprop, _ := NewMatrix(nd, nd)
+	gprop.Expm(g)
+	uref := NewVector(nd)
+	uref.MulVec(gprop, u0)
+	_, err = u0.ExpmMulVec(g, 1.0, u0)
+	if err != nil || !u0.ApproxEquals(uref, 1.0e-12) {
+		t.Errorf("Action of exponential error u=%s want=%s", u0.String(), uref.String())
+	}
+
+	// Stiff, with a large norm, must not take a step per unit of norm.
+	k, _ := NewMatrixFromArray([][]float64{{-1.0e7, 0.0}, {0.0, -1.0}})
+	w := NewVectorFromArray([]float64{1.0, 1.0})
+	_, err = w.ExpmMulVec(k, 10.0, w)
+	if err != nil || w.Data[0] != 0.0 || math.Abs(w.Data[1]/math.Exp(-10.0)-1.0) > 1.0e-7 {
+		t.Errorf("Action of exponential error w=%s want=[0, %g]", w.String(), math.Exp(-10.0))
+	}
+
+	nan, _ := NewMatrixFromArray([][]float64{{math.NaN(), 0.0}, {0.0, 1.0}})
+	_, err = e.Expm(nan)
+	if err == nil {
+		t.Errorf("Expm should have rejected a non-finite norm.")
+	}
+	inf, _ := NewMatrixFromArray([][]float64{{math.Inf(1), 0.0}, {0.0, 1.0}})
+	_, err = w.ExpmMulVec(inf, 1.0, w)
+	if err == nil {
+		t.Errorf("ExpmMulVec should have rejected a non-finite norm.")
+	}
+}
@@ -0,0 +1,847 @@
+// generic.go
+// Vectors and matrices of any of the floating-point or complex types,
+// using type parameters.
+//
+// VectorOf[T] and MatrixOf[T] offer the Vector and Matrix API, with the
+// same pre-allocated-receiver conventions, for float32 data (large data
+// sets) and complex data (frequency-domain work). Left out are the
+// order-based reductions (Min, Max, ArgMin, ArgMax, Variance, StdDev),
+// which have no meaning for complex values, and the factorisations
+// (LU, QR, Cholesky, eigenvalues, SVD, Expm), which stay float64-only.
+// Dot is a method, with VectorOfDot kept as the free-function form.
+// Vector and Matrix stay as distinct float64 types because Go does not
+// allow methods to be declared on an instantiated generic type, and so
+// the float64-only methods could not be kept on an alias.
+// Instead, the algorithms common to both forms are written once, as
+// generic functions over the bare slices, and the methods of Vector and
+// Matrix are thin wrappers around their float64 instantiations.
+// The adapters VectorOf64/MatrixOf64 and VectorFromGeneric/
+// MatrixFromGeneric convert between the two forms while sharing storage.
+//
+// PJ 2026-10-18
+
+package array
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+type Number interface {
+	float32 | float64 | complex64 | complex128
+}
+
+// Magnitude of a value of any of the Number types.
+func absOf[T Number](x T) float64 {
+	switch v := any(x).(type) {
+	case float32:
+		return math.Abs(float64(v))
+	case float64:
+		return math.Abs(v)
+	case complex64:
+		return cmplx.Abs(complex128(v))
+	case complex128:
+		return cmplx.Abs(v)
+	}
+	return math.NaN()
+}
+
+// Convert a real value to any of the Number types.
+func fromFloat[T Number](x float64) T {
+	var z T
+	switch any(z).(type) {
+	case float32:
+		return any(float32(x)).(T)
+	case float64:
+		return any(x).(T)
+	case complex64:
+		return any(complex(float32(x), 0)).(T)
+	case complex128:
+		return any(complex(x, 0)).(T)
+	}
+	return z
+}
+
+// Relative comparison for large numbers, absolute comparison for small numbers
+func approxEqualsOf[T Number](a, b T, tol float64) bool {
+	return absOf(a-b)/(0.5*(absOf(a)+absOf(b)+1.0)) <= tol
+}
+
+//-----------------------------------------------------------------------------
+
+type VectorOf[T Number] struct {
+	Data []T
+}
+
+func NewVectorOf[T Number](n int) *VectorOf[T] {
+	return &VectorOf[T]{Data: make([]T, n)}
+}
+
+func NewVectorOfFromArray[T Number](data []T) *VectorOf[T] {
+	z := VectorOf[T]{Data: make([]T, len(data))}
+	copy(z.Data, data)
+	return &z
+}
+
+func (v VectorOf[T]) IsEmpty() bool {
+	return len(v.Data) == 0
+}
+
+func (a *VectorOf[T]) Clone() *VectorOf[T] {
+	return NewVectorOfFromArray(a.Data)
+}
+
+// Generate a JSON-like string representation.
+// Complex values are written in Go's (re+imi) form.
+func (a *VectorOf[T]) String() string {
+	var b bytes.Buffer
+	n := len(a.Data)
+	b.WriteString("[")
+	for i, d := range a.Data {
+		b.WriteString(fmt.Sprintf("%g", d))
+		if i+1 < n {
+			b.WriteString(", ")
+		}
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+func (z *VectorOf[T]) SetFromScalar(a T) *VectorOf[T] {
+	for i := range z.Data {
+		z.Data[i] = a
+	}
+	return z
+}
+
+func (z *VectorOf[T]) SetFromVector(a *VectorOf[T]) (*VectorOf[T], error) {
+	if len(z.Data) != len(a.Data) {
+		msg := fmt.Sprintf("Inconsistent array lengths z:%v a:%v", len(z.Data), len(a.Data))
+		return z, errors.New(msg)
+	}
+	copy(z.Data, a.Data)
+	return z, nil
+}
+
+func (a *VectorOf[T]) Sum() T {
+	var s T
+	for _, d := range a.Data {
+		s += d
+	}
+	return s
+}
+
+func (a *VectorOf[T]) Mean() T {
+	n := len(a.Data)
+	if n == 0 {
+		return 0
+	}
+	return a.Sum() / fromFloat[T](float64(n))
+}
+
+// Euclidian (L2) norm, always real.
+func (a *VectorOf[T]) Mag() float64 {
+	s := 0.0
+	for _, d := range a.Data {
+		m := absOf(d)
+		s += m * m
+	}
+	return math.Sqrt(s)
+}
+
+func (z *VectorOf[T]) Normalize() *VectorOf[T] {
+	mag := z.Mag()
+	if mag == 0.0 {
+		return z
+	}
+	for i := range z.Data {
+		z.Data[i] /= fromFloat[T](mag)
+	}
+	return z
+}
+
+func (a *VectorOf[T]) ApproxEquals(other *VectorOf[T], tol float64) bool {
+	if len(a.Data) != len(other.Data) {
+		return false
+	}
+	for i := range a.Data {
+		if !approxEqualsOf(a.Data[i], other.Data[i], tol) {
+			return false
+		}
+	}
+	return true
+}
+
+func (z *VectorOf[T]) Scale(a T) *VectorOf[T] {
+	for i := range z.Data {
+		z.Data[i] *= a
+	}
+	return z
+}
+
+func (z *VectorOf[T]) Add(a, b *VectorOf[T]) (*VectorOf[T], error) {
+	return z.Blend(a, b, 1, 1)
+}
+
+func (z *VectorOf[T]) Sub(a, b *VectorOf[T]) (*VectorOf[T], error) {
+	return z.Blend(a, b, 1, -1)
+}
+
+func (z *VectorOf[T]) Blend(a, b *VectorOf[T], sa, sb T) (*VectorOf[T], error) {
+	n := len(z.Data)
+	if n != len(a.Data) || n != len(b.Data) {
+		msg := fmt.Sprintf("Inconsistent array lengths z:%v a:%v b:%v",
+			len(z.Data), len(a.Data), len(b.Data))
+		return z, errors.New(msg)
+	}
+	for i := 0; i < n; i++ {
+		z.Data[i] = sa*a.Data[i] + sb*b.Data[i]
+	}
+	return z, nil
+}
+
+// Sum of a[i]*b[i], as for VectorDot.
+// For complex vectors, no conjugate is taken.
+func (a *VectorOf[T]) Dot(b *VectorOf[T]) (T, error) {
+	if len(a.Data) != len(b.Data) {
+		msg := fmt.Sprintf("Inconsistent array lengths a:%v b:%v", len(a.Data), len(b.Data))
+		return 0, errors.New(msg)
+	}
+	var s T
+	for i := range a.Data {
+		s += a.Data[i] * b.Data[i]
+	}
+	return s, nil
+}
+
+func VectorOfDot[T Number](a, b *VectorOf[T]) (T, error) {
+	return a.Dot(b)
+}
+
+// Norms, which are always real.
+
+func (a *VectorOf[T]) Norm1() float64 {
+	return norm1Of(a.Data)
+}
+
+func (a *VectorOf[T]) NormInf() float64 {
+	return normInfOf(a.Data)
+}
+
+// General p-norm, for p >= 1, with p = +Inf giving NormInf.
+func (a *VectorOf[T]) NormP(p float64) (float64, error) {
+	return normPOf(a.Data, p)
+}
+
+// Element-wise arithmetic, following the conventions of Add.
+
+func (z *VectorOf[T]) Mul(a, b *VectorOf[T]) (*VectorOf[T], error) {
+	n := len(z.Data)
+	if n != len(a.Data) || n != len(b.Data) {
+		msg := fmt.Sprintf("Inconsistent array lengths z:%v a:%v b:%v",
+			len(z.Data), len(a.Data), len(b.Data))
+		return z, errors.New(msg)
+	}
+	for i := 0; i < n; i++ {
+		z.Data[i] = a.Data[i] * b.Data[i]
+	}
+	return z, nil
+}
+
+func (z *VectorOf[T]) Div(a, b *VectorOf[T]) (*VectorOf[T], error) {
+	n := len(z.Data)
+	if n != len(a.Data) || n != len(b.Data) {
+		msg := fmt.Sprintf("Inconsistent array lengths z:%v a:%v b:%v",
+			len(z.Data), len(a.Data), len(b.Data))
+		return z, errors.New(msg)
+	}
+	for i := 0; i < n; i++ {
+		z.Data[i] = a.Data[i] / b.Data[i]
+	}
+	return z, nil
+}
+
+// z = alpha*x + y
+func (z *VectorOf[T]) Axpy(alpha T, x, y *VectorOf[T]) (*VectorOf[T], error) {
+	n := len(z.Data)
+	if n != len(x.Data) || n != len(y.Data) {
+		msg := fmt.Sprintf("Inconsistent array lengths z:%v x:%v y:%v",
+			len(z.Data), len(x.Data), len(y.Data))
+		return z, errors.New(msg)
+	}
+	for i := 0; i < n; i++ {
+		z.Data[i] = alpha*x.Data[i] + y.Data[i]
+	}
+	return z, nil
+}
+
+// z[i] = f(a[i])
+func (z *VectorOf[T]) Apply(f func(T) T, a *VectorOf[T]) (*VectorOf[T], error) {
+	n := len(z.Data)
+	if n != len(a.Data) {
+		msg := fmt.Sprintf("Inconsistent array lengths z:%v a:%v", len(z.Data), len(a.Data))
+		return z, errors.New(msg)
+	}
+	for i := 0; i < n; i++ {
+		z.Data[i] = f(a.Data[i])
+	}
+	return z, nil
+}
+
+// Cumulative sum, z[i] = a[0] + ... + a[i]
+func (z *VectorOf[T]) CumSum(a *VectorOf[T]) (*VectorOf[T], error) {
+	n := len(z.Data)
+	if n != len(a.Data) {
+		msg := fmt.Sprintf("Inconsistent array lengths z:%v a:%v", len(z.Data), len(a.Data))
+		return z, errors.New(msg)
+	}
+	var s T
+	for i := 0; i < n; i++ {
+		s += a.Data[i]
+		z.Data[i] = s
+	}
+	return z, nil
+}
+
+// Tridiagonal solve by the Thomas algorithm, as for Vector.SolveTridiagonal.
+func (x *VectorOf[T]) SolveTridiagonal(a, b, c, d *VectorOf[T], opts ...SolverOptions) (*VectorOf[T], error) {
+	return x, solveTridiagonalOf(x.Data, a.Data, b.Data, c.Data, d.Data, opts)
+}
+
+//-----------------------------------------------------------------------------
+
+type MatrixOf[T Number] struct {
+	Data [][]T
+}
+
+func NewMatrixOf[T Number](nrows, ncols int) (*MatrixOf[T], error) {
+	if nrows <= 0 {
+		msg := fmt.Sprintf("Invalid value for nrows=%v", nrows)
+		return nil, errors.New(msg)
+	}
+	if ncols <= 0 {
+		msg := fmt.Sprintf("Invalid value for ncols=%v", ncols)
+		return nil, errors.New(msg)
+	}
+	return &MatrixOf[T]{Data: newDataOf[T](nrows, ncols)}, nil
+}
+
+func NewMatrixOfFromArray[T Number](data [][]T) (*MatrixOf[T], error) {
+	nrows := len(data)
+	if nrows == 0 {
+		return nil, errors.New("Zero rows")
+	}
+	ncols0 := len(data[0])
+	for i := 0; i < nrows; i++ {
+		if len(data[i]) != ncols0 {
+			msg := fmt.Sprintf("Ragged rows: ncols0=%d ncols[%d]=%d", ncols0, i, len(data[i]))
+			return nil, errors.New(msg)
+		}
+	}
+	z, err := NewMatrixOf[T](nrows, ncols0)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < nrows; i++ {
+		copy(z.Data[i], data[i])
+	}
+	return z, nil
+}
+
+func (a *MatrixOf[T]) IsEmpty() bool {
+	return len(a.Data) == 0
+}
+
+func (a *MatrixOf[T]) Dims() (int, int) {
+	return dimsOf(a.Data)
+}
+
+func NewIdentityMatrixOf[T Number](n int) (*MatrixOf[T], error) {
+	z, err := NewMatrixOf[T](n, n)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < n; i++ {
+		z.Data[i][i] = 1
+	}
+	return z, nil
+}
+
+func (a *MatrixOf[T]) Clone() *MatrixOf[T] {
+	z, _ := NewMatrixOfFromArray(a.Data)
+	return z
+}
+
+// As for Matrix.IsSymmetric; no conjugate is taken for complex matrices.
+func (a *MatrixOf[T]) IsSymmetric(tol float64) bool {
+	n := len(a.Data)
+	if n == 0 || len(a.Data[0]) != n {
+		return false
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if !approxEqualsOf(a.Data[i][j], a.Data[j][i], tol) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (a *MatrixOf[T]) String() string {
+	var b bytes.Buffer
+	nrows := len(a.Data)
+	b.WriteString("[")
+	for i := 0; i < nrows; i++ {
+		row := VectorOf[T]{Data: a.Data[i]}
+		b.WriteString(row.String())
+		if i+1 < nrows {
+			b.WriteString(", ")
+		}
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+func (a *MatrixOf[T]) ApproxEquals(other *MatrixOf[T], tol float64) bool {
+	if len(a.Data) != len(other.Data) {
+		return false
+	}
+	for i := range a.Data {
+		ai := VectorOf[T]{Data: a.Data[i]}
+		bi := VectorOf[T]{Data: other.Data[i]}
+		if !ai.ApproxEquals(&bi, tol) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *MatrixOf[T]) NormInf() float64 {
+	norm := 0.0
+	for _, row := range a.Data {
+		rowsum := 0.0
+		for _, d := range row {
+			rowsum += absOf(d)
+		}
+		norm = math.Max(rowsum, norm)
+	}
+	return norm
+}
+
+// The aliasing rules are the same as for the Matrix functions.
+
+func (z *MatrixOf[T]) SetFromMatrix(a *MatrixOf[T]) (*MatrixOf[T], error) {
+	nrows, ncols := z.Dims()
+	ar, ac := a.Dims()
+	if nrows != ar || ncols != ac {
+		return z, dimsErrorOf(z.Data, a.Data)
+	}
+	for i := 0; i < nrows; i++ {
+		copy(z.Data[i], a.Data[i])
+	}
+	return z, nil
+}
+
+func (z *MatrixOf[T]) Add(a, b *MatrixOf[T]) (*MatrixOf[T], error) {
+	return z.blend(a, b, 1)
+}
+
+func (z *MatrixOf[T]) Sub(a, b *MatrixOf[T]) (*MatrixOf[T], error) {
+	return z.blend(a, b, -1)
+}
+
+func (z *MatrixOf[T]) blend(a, b *MatrixOf[T], sb T) (*MatrixOf[T], error) {
+	nrows, ncols := z.Dims()
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if nrows != ar || nrows != br || ncols != ac || ncols != bc {
+		return z, dimsErrorOf(z.Data, a.Data, b.Data)
+	}
+	for i := 0; i < nrows; i++ {
+		for j := 0; j < ncols; j++ {
+			z.Data[i][j] = a.Data[i][j] + sb*b.Data[i][j]
+		}
+	}
+	return z, nil
+}
+
+func (z *MatrixOf[T]) Scale(s T) *MatrixOf[T] {
+	for i := range z.Data {
+		for j := range z.Data[i] {
+			z.Data[i][j] *= s
+		}
+	}
+	return z
+}
+
+func (z *MatrixOf[T]) Mul(a, b *MatrixOf[T]) (*MatrixOf[T], error) {
+	return z, mulOf(z.Data, a.Data, b.Data)
+}
+
+// Plain transpose; no conjugate is taken for complex matrices.
+func (z *MatrixOf[T]) Transpose(a *MatrixOf[T]) (*MatrixOf[T], error) {
+	return z, transposeOf(z.Data, a.Data)
+}
+
+func (y *VectorOf[T]) MulVec(a *MatrixOf[T], x *VectorOf[T]) (*VectorOf[T], error) {
+	return y, mulVecOf(y.Data, a.Data, x.Data)
+}
+
+// Gauss-Jordan elimination on an augmented matrix, as for Matrix,
+// with pivots selected by magnitude.
+func (c *MatrixOf[T]) GaussJordanElimination(opts ...SolverOptions) (*MatrixOf[T], error) {
+	return c, gaussJordanOf(c.Data, opts)
+}
+
+// Integer power z = a^k, by repeated squaring, as for Matrix.Pow.
+// Negative powers are computed from the inverse of a.
+// The receiver may alias a.
+func (z *MatrixOf[T]) Pow(a *MatrixOf[T], k int) (*MatrixOf[T], error) {
+	return z, powOf(z.Data, a.Data, k)
+}
+
+//-----------------------------------------------------------------------------
+// Algorithms shared by Vector/Matrix and VectorOf/MatrixOf.
+// Each is written once, over the bare slices, and the methods
+// of both forms are thin wrappers around it.
+
+// Rows of n elements, in a single backing store.
+func newDataOf[T Number](nrows, ncols int) [][]T {
+	data := make([][]T, nrows)
+	store := make([]T, nrows*ncols)
+	for i := 0; i < nrows; i++ {
+		data[i] = store[i*ncols : (i+1)*ncols : (i+1)*ncols]
+	}
+	return data
+}
+
+func cloneDataOf[T Number](a [][]T) [][]T {
+	ncols := 0
+	if len(a) > 0 {
+		ncols = len(a[0])
+	}
+	z := newDataOf[T](len(a), ncols)
+	for i := range a {
+		copy(z[i], a[i])
+	}
+	return z
+}
+
+func dimsOf[T Number](a [][]T) (int, int) {
+	if len(a) == 0 {
+		return 0, 0
+	}
+	return len(a), len(a[0])
+}
+
+func dimsErrorOf[T Number](z, a [][]T, b ...[][]T) error {
+	zr, zc := dimsOf(z)
+	ar, ac := dimsOf(a)
+	msg := fmt.Sprintf("Inconsistent matrix dimensions z:%dx%d a:%dx%d", zr, zc, ar, ac)
+	if len(b) > 0 {
+		br, bc := dimsOf(b[0])
+		msg += fmt.Sprintf(" b:%dx%d", br, bc)
+	}
+	return errors.New(msg)
+}
+
+// True if any row of a overlaps any row of b, or they share their row slices.
+func aliasesOf[T Number](a, b [][]T) bool {
+	if len(a) > 0 && len(b) > 0 && &a[0] == &b[0] {
+		return true
+	}
+	for _, r := range a {
+		if rowsOverlap(b, r) {
+			return true
+		}
+	}
+	return false
+}
+
+func norm1Of[T Number](data []T) float64 {
+	s := 0.0
+	for _, d := range data {
+		s += absOf(d)
+	}
+	return s
+}
+
+func normInfOf[T Number](data []T) float64 {
+	s := 0.0
+	for _, d := range data {
+		s = math.Max(s, absOf(d))
+	}
+	return s
+}
+
+func normPOf[T Number](data []T, p float64) (float64, error) {
+	if p < 1.0 || math.IsNaN(p) {
+		msg := fmt.Sprintf("Invalid value for p=%v", p)
+		return 0.0, errors.New(msg)
+	}
+	if math.IsInf(p, 1) {
+		return normInfOf(data), nil
+	}
+	// Scale by the largest magnitude to avoid overflow.
+	scale := normInfOf(data)
+	if scale == 0.0 {
+		return 0.0, nil
+	}
+	s := 0.0
+	for _, d := range data {
+		s += math.Pow(absOf(d)/scale, p)
+	}
+	return scale * math.Pow(s, 1.0/p), nil
+}
+
+// Thomas algorithm, without pivoting.
+func solveTridiagonalOf[T Number](x, a, b, c, d []T, opts []SolverOptions) error {
+	n := len(b)
+	if n == 0 || len(a) != n || len(c) != n || len(d) != n || len(x) != n {
+		msg := fmt.Sprintf("Inconsistent array lengths a:%v b:%v c:%v d:%v x:%v",
+			len(a), len(b), len(c), len(d), len(x))
+		return errors.New(msg)
+	}
+	scale := 0.0
+	for i := 0; i < n; i++ {
+		scale = math.Max(scale, absOf(a[i])+absOf(b[i])+absOf(c[i]))
+	}
+	tiny := solverOptions(opts).pivotThreshold(scale)
+	cp := make([]T, n)
+	beta := b[0]
+	if absOf(beta) <= tiny {
+		return errors.New(fmt.Sprintf("Singular with pivot=%v", beta))
+	}
+	x[0] = d[0] / beta
+	for i := 1; i < n; i++ {
+		cp[i-1] = c[i-1] / beta
+		beta = b[i] - a[i]*cp[i-1]
+		if absOf(beta) <= tiny {
+			return errors.New(fmt.Sprintf("Singular with pivot=%v", beta))
+		}
+		x[i] = (d[i] - a[i]*x[i-1]) / beta
+	}
+	for i := n - 2; i >= 0; i-- {
+		x[i] -= cp[i] * x[i+1]
+	}
+	return nil
+}
+
+// Matrix product z = a.b, with z not aliasing a or b.
+func mulOf[T Number](z, a, b [][]T) error {
+	nrows, ncols := dimsOf(z)
+	ar, ac := dimsOf(a)
+	br, bc := dimsOf(b)
+	if nrows != ar || ncols != bc || ac != br {
+		return dimsErrorOf(z, a, b)
+	}
+	if aliasesOf(z, a) || aliasesOf(z, b) {
+		return errors.New("Result matrix must not alias an argument of Mul")
+	}
+	for i := 0; i < nrows; i++ {
+		zi := z[i]
+		for j := 0; j < ncols; j++ {
+			zi[j] = 0
+		}
+		// Loop order i-k-j so that we run along the rows of b.
+		for k := 0; k < ac; k++ {
+			aik := a[i][k]
+			if aik == 0 {
+				continue
+			}
+			bk := b[k]
+			for j := 0; j < ncols; j++ {
+				zi[j] += aik * bk[j]
+			}
+		}
+	}
+	return nil
+}
+
+// Plain transpose, in place only for a square matrix.
+func transposeOf[T Number](z, a [][]T) error {
+	nrows, ncols := dimsOf(z)
+	ar, ac := dimsOf(a)
+	if nrows != ac || ncols != ar {
+		return dimsErrorOf(z, a)
+	}
+	if aliasesOf(z, a) {
+		// Only possible for a square matrix, given the check above.
+		for i := 0; i < nrows; i++ {
+			for j := i + 1; j < ncols; j++ {
+				z[i][j], z[j][i] = z[j][i], z[i][j]
+			}
+		}
+		return nil
+	}
+	for i := 0; i < nrows; i++ {
+		for j := 0; j < ncols; j++ {
+			z[i][j] = a[j][i]
+		}
+	}
+	return nil
+}
+
+// Matrix-vector product y = a.x, with y not overlapping x or a.
+func mulVecOf[T Number](y []T, a [][]T, x []T) error {
+	nrows, ncols := dimsOf(a)
+	if len(y) != nrows || len(x) != ncols {
+		msg := fmt.Sprintf("Inconsistent dimensions y:%v a:%dx%d x:%v",
+			len(y), nrows, ncols, len(x))
+		return errors.New(msg)
+	}
+	if overlaps(y, x) || rowsOverlap(a, y) {
+		return errors.New("Result vector must not alias an argument of MulVec")
+	}
+	for i := 0; i < nrows; i++ {
+		var s T
+		for j := 0; j < ncols; j++ {
+			s += a[i][j] * x[j]
+		}
+		y[i] = s
+	}
+	return nil
+}
+
+// Gauss-Jordan elimination on the augmented matrix c = [A|b],
+// with pivots selected by magnitude.
+func gaussJordanOf[T Number](c [][]T, opts []SolverOptions) error {
+	nrows := len(c)
+	if nrows == 0 {
+		return errors.New("Empty Matrix")
+	}
+	ncols := len(c[0])
+	if ncols == 0 {
+		return errors.New("Empty rows in Matrix")
+	}
+	scale := 0.0
+	for i := 0; i < nrows; i++ {
+		rowsum := 0.0
+		for j := 0; j < nrows && j < len(c[i]); j++ {
+			rowsum += absOf(c[i][j])
+		}
+		scale = math.Max(scale, rowsum)
+	}
+	tiny := solverOptions(opts).pivotThreshold(scale)
+	for j := 0; j < nrows; j++ {
+		// Select pivot, the largest magnitude in column j.
+		p := j
+		for i := j + 1; i < nrows; i++ {
+			if absOf(c[i][j]) > absOf(c[p][j]) {
+				p = i
+			}
+		}
+		if absOf(c[p][j]) <= tiny {
+			return errors.New(fmt.Sprintf("Singular with pivot=%v", c[p][j]))
+		}
+		if p != j {
+			// Swap rows, to get pivot onto the diagonal.
+			c[p], c[j] = c[j], c[p]
+		}
+		// Scale row j to get unity on the diagonal.
+		cjj := c[j][j]
+		for col := 0; col < ncols; col++ {
+			c[j][col] /= cjj
+		}
+		// Do the elimination to get zeros in all off-diagonal values in column j.
+		for i := 0; i < nrows; i++ {
+			if i == j {
+				continue
+			}
+			cij := c[i][j]
+			for col := 0; col < ncols; col++ {
+				c[i][col] -= cij * c[j][col]
+			}
+		}
+	}
+	return nil
+}
+
+// Inverse of the square matrix a, by Gauss-Jordan elimination of [A|I].
+func inverseOf[T Number](a [][]T, opts []SolverOptions) ([][]T, error) {
+	n := len(a)
+	c := newDataOf[T](n, 2*n)
+	for i := 0; i < n; i++ {
+		copy(c[i], a[i])
+		c[i][n+i] = 1
+	}
+	err := gaussJordanOf(c, opts)
+	if err != nil {
+		return nil, err
+	}
+	z := newDataOf[T](n, n)
+	for i := 0; i < n; i++ {
+		copy(z[i], c[i][n:])
+	}
+	return z, nil
+}
+
+// Integer power z = a^k, by repeated squaring.
+// Negative powers are computed from the inverse of a.
+// The receiver may alias a.
+func powOf[T Number](z, a [][]T, k int) error {
+	n, ncols := dimsOf(a)
+	if n == 0 {
+		return errors.New("Empty Matrix")
+	}
+	if n != ncols {
+		msg := fmt.Sprintf("Matrix is not square: nrows=%d ncols=%d", n, ncols)
+		return errors.New(msg)
+	}
+	if zr, zc := dimsOf(z); zr != n || zc != n {
+		return dimsErrorOf(z, a)
+	}
+	base := cloneDataOf(a)
+	if k < 0 {
+		var err error
+		base, err = inverseOf(a, nil)
+		if err != nil {
+			return err
+		}
+		k = -k
+	}
+	result := newDataOf[T](n, n)
+	for i := 0; i < n; i++ {
+		result[i][i] = 1
+	}
+	tmp := newDataOf[T](n, n)
+	for k > 0 {
+		if k%2 == 1 {
+			mulOf(tmp, result, base)
+			result, tmp = tmp, result
+		}
+		k /= 2
+		if k > 0 {
+			mulOf(tmp, base, base)
+			base, tmp = tmp, base
+		}
+	}
+	for i := 0; i < n; i++ {
+		copy(z[i], result[i])
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+// Adapters between the float64 instantiations and Vector/Matrix.
+// The storage is shared, not copied.
+
+func (v *Vector) VectorOf64() *VectorOf[float64] {
+	return &VectorOf[float64]{Data: v.Data}
+}
+
+func VectorFromGeneric(v *VectorOf[float64]) *Vector {
+	return &Vector{Data: v.Data}
+}
+
+func (a *Matrix) MatrixOf64() *MatrixOf[float64] {
+	return &MatrixOf[float64]{Data: a.Data}
+}
+
+func MatrixFromGeneric(a *MatrixOf[float64]) *Matrix {
+	return &Matrix{Data: a.Data}
+}
@@ -0,0 +1,203 @@
+// generic_test.go
+// Try out the generic vectors and matrices for float32 and complex data.
+// PJ 2026-10-18
+//
+
+package array
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestVectorOf(t *testing.T) {
+	v1 := NewVectorOfFromArray([]float32{1.0, 2.0, 3.0})
+	v2 := NewVectorOf[float32](3).SetFromScalar(1.0)
+	v3 := NewVectorOf[float32](3)
+	v3.Add(v1, v2)
+	v3ref := NewVectorOfFromArray([]float32{2.0, 3.0, 4.0})
+	if !v3.ApproxEquals(v3ref, 1.0e-6) || v3.Sum() != 9.0 {
+		t.Errorf("Float32 vector Add error v3= %v want= %v", v3.String(), v3ref.String())
+	}
+	d, err := VectorOfDot(v1, v3)
+	if err != nil || d != 20.0 {
+		t.Errorf("Float32 vector dot error d= %v want= 20.0", d)
+	}
+	_, err = v3.Add(v1, NewVectorOf[float32](2))
+	if err == nil {
+		t.Errorf("Vector Add should have detected mismatch in lengths.")
+	}
+
+	c1 := NewVectorOfFromArray([]complex128{3 + 4i, 0})
+	if math.Abs(c1.Mag() - 5.0) > 1.0e-9 {
+		t.Errorf("Complex vector Mag error mag= %v want= 5.0", c1.Mag())
+	}
+	c1.Normalize()
+	c1ref := NewVectorOfFromArray([]complex128{0.6 + 0.8i, 0})
+	if !c1.ApproxEquals(c1ref, 1.0e-9) {
+		t.Errorf("Complex vector Normalize error c1= %v want= %v", c1.String(), c1ref.String())
+	}
+
+	// The float64 adapter shares storage with Vector.
+	v := NewVectorFromArray([]float64{1.0, 2.0})
+	g := v.VectorOf64()
+	g.Scale(3.0)
+	if v.Data[1] != 6.0 || VectorFromGeneric(g).Sum() != 9.0 {
+		t.Errorf("Float64 adapter does not share storage v= %v", v.String())
+	}
+}
+
+func TestMatrixOf(t *testing.T) {
+	// Complex system (1+i).x + y = 2+i, x - i.y = 0, with solution x = 1, y = -i.
+	c, err := NewMatrixOfFromArray([][]complex128{{1 + 1i, 1, 1 + 1i}, {1, -1i, 2}})
+	if err != nil {
+		t.Fatalf("Failed to construct complex matrix, err: %s", err)
+	}
+	a := c.Clone()
+	_, err = c.GaussJordanElimination()
+	if err != nil {
+		t.Fatalf("Complex elimination failed, err: %s", err)
+	}
+	x := NewVectorOfFromArray([]complex128{c.Data[0][2], c.Data[1][2]})
+	// Check the solution against the original system.
+	a2, _ := NewMatrixOfFromArray([][]complex128{a.Data[0][:2], a.Data[1][:2]})
+	b := NewVectorOf[complex128](2)
+	b.MulVec(a2, x)
+	bref := NewVectorOfFromArray([]complex128{a.Data[0][2], a.Data[1][2]})
+	if !b.ApproxEquals(bref, 1.0e-9) {
+		t.Errorf("Complex elimination error A.x= %v want= %v", b.String(), bref.String())
+	}
+
+	f1, _ := NewMatrixOfFromArray([][]float32{{1.0, 2.0}, {3.0, 4.0}})
+	f2, _ := NewMatrixOf[float32](2, 2)
+	f2.Transpose(f1)
+	f3, _ := NewMatrixOf[float32](2, 2)
+	_, err = f3.Mul(f1, f2)
+	f3ref, _ := NewMatrixOfFromArray([][]float32{{5.0, 11.0}, {11.0, 25.0}})
+	if err != nil || !f3.ApproxEquals(f3ref, 1.0e-6) {
+		t.Errorf("Float32 matrix Mul error f3= %v want= %v", f3.String(), f3ref.String())
+	}
+	f3.Sub(f3, f3ref)
+	if f3.NormInf() != 0.0 {
+		t.Errorf("Float32 matrix Sub error f3= %v", f3.String())
+	}
+	_, err = f3.Mul(f3, f1)
+	if err == nil {
+		t.Errorf("Matrix Mul should have detected aliasing of result.")
+	}
+	w := NewVectorOfFromArray([]float32{1.0, 1.0, 1.0})
+	_, err = (&VectorOf[float32]{Data: w.Data[1:3]}).MulVec(f1, &VectorOf[float32]{Data: w.Data[0:2]})
+	if err == nil {
+		t.Errorf("MulVec should have detected overlap of result with x.")
+	}
+
+	m, _ := NewMatrixFromArray([][]float64{{1.0, 2.0}, {3.0, 4.0}})
+	g := m.MatrixOf64()
+	g.Scale(2.0)
+	if m.Data[1][1] != 8.0 || MatrixFromGeneric(g).NormInf() != 14.0 {
+		t.Errorf("Float64 adapter does not share storage m= %v", m.String())
+	}
+}
+
+func TestVectorOfParity(t *testing.T) {
+	a := NewVectorOfFromArray([]complex128{3 + 4i, -1, 2i})
+	if a.Norm1() != 8.0 || a.NormInf() != 5.0 {
+		t.Errorf("Complex vector norms error norm1= %v normInf= %v", a.Norm1(), a.NormInf())
+	}
+	p2, err := a.NormP(2.0)
+	if err != nil || math.Abs(p2-a.Mag()) > 1.0e-12 {
+		t.Errorf("Complex vector NormP error p2= %v want= %v", p2, a.Mag())
+	}
+	b := NewVectorOfFromArray([]complex128{1i, 2, 1})
+	d, _ := a.Dot(b)
+	if d != -6+5i {
+		t.Errorf("Complex vector Dot error d= %v", d)
+	}
+	z := NewVectorOf[complex128](3)
+	z.Mul(a, b)
+	z.Div(z, b)
+	if !z.ApproxEquals(a, 1.0e-12) {
+		t.Errorf("Complex vector Mul/Div error z= %v want= %v", z.String(), a.String())
+	}
+	z.Axpy(1i, a, b)
+	zref := NewVectorOfFromArray([]complex128{-4 + 4i, 2 - 1i, -1})
+	if !z.ApproxEquals(zref, 1.0e-12) {
+		t.Errorf("Complex vector Axpy error z= %v want= %v", z.String(), zref.String())
+	}
+	f := NewVectorOfFromArray([]float32{1.0, 2.0, 3.0})
+	g := NewVectorOf[float32](3)
+	g.Apply(func(x float32) float32 { return x * x }, f)
+	g.CumSum(g)
+	gref := NewVectorOfFromArray([]float32{1.0, 5.0, 14.0})
+	if !g.ApproxEquals(gref, 1.0e-6) {
+		t.Errorf("Float32 vector Apply/CumSum error g= %v want= %v", g.String(), gref.String())
+	}
+	// Tridiagonal system with the solution x = [1, 2, 3].
+	x := NewVectorOf[float32](3)
+	_, err = x.SolveTridiagonal(NewVectorOfFromArray([]float32{0.0, 1.0, 1.0}),
+		NewVectorOfFromArray([]float32{4.0, 4.0, 4.0}), NewVectorOfFromArray([]float32{1.0, 1.0, 0.0}),
+		NewVectorOfFromArray([]float32{6.0, 12.0, 14.0}))
+	if err != nil || !x.ApproxEquals(f, 1.0e-6) {
+		t.Errorf("Float32 tridiagonal solve error x= %v err= %v", x.String(), err)
+	}
+
+	buf, err := json.Marshal(a)
+	want := `[[3,4],[-1,0],[0,2]]`
+	if err != nil || string(buf) != want {
+		t.Errorf("Complex vector JSON encoding error got= %s want= %s", string(buf), want)
+	}
+	var a2 VectorOf[complex128]
+	err = json.Unmarshal(buf, &a2)
+	if err != nil || !a2.ApproxEquals(a, 0.0) {
+		t.Errorf("Complex vector JSON decoding error a2= %v err= %v", a2.String(), err)
+	}
+	buf, _ = json.Marshal(NewVectorOfFromArray([]float32{0.1, float32(math.Inf(1))}))
+	if string(buf) != `[0.1,"Infinity"]` {
+		t.Errorf("Float32 vector JSON encoding error got= %s", string(buf))
+	}
+	err = json.Unmarshal([]byte(`[[1, 2, 3]]`), &a2)
+	if err == nil {
+		t.Errorf("Did not detect invalid complex element.")
+	}
+}
+
+func TestMatrixOfParity(t *testing.T) {
+	c, _ := NewMatrixOfFromArray([][]complex128{{1, 2i}, {2i, 3}})
+	if !c.IsSymmetric(1.0e-12) {
+		t.Errorf("Complex symmetric matrix not detected c= %v", c.String())
+	}
+	c.Data[1][0] = -2i
+	if c.IsSymmetric(1.0e-12) {
+		t.Errorf("Hermitian matrix reported as symmetric c= %v", c.String())
+	}
+	// The generic power agrees with Matrix.Pow, including negative powers.
+	m, _ := NewMatrixFromArray([][]float64{{2.0, 1.0}, {1.0, 3.0}})
+	for _, k := range []int{0, 1, 5, -2} {
+		want, _ := NewMatrix(2, 2)
+		want.Pow(m, k)
+		got, _ := NewMatrixOf[float64](2, 2)
+		_, err := got.Pow(m.MatrixOf64(), k)
+		if err != nil || !MatrixFromGeneric(got).ApproxEquals(want, 1.0e-12) {
+			t.Errorf("Generic Pow error k= %d got= %v want= %v", k, got.String(), want.String())
+		}
+	}
+	// The square of the rotation by i is -1.
+	r, _ := NewMatrixOfFromArray([][]complex64{{1i}})
+	r.Pow(r, 2)
+	if r.Data[0][0] != -1 {
+		t.Errorf("Complex Pow error r= %v", r.String())
+	}
+	s, _ := NewMatrixOfFromArray([][]float32{{1.0, 2.0}, {2.0, 4.0}})
+	_, err := s.Pow(s, -1)
+	if err == nil {
+		t.Errorf("Did not detect singular matrix for negative power.")
+	}
+
+	buf, err := json.Marshal(c)
+	var c2 MatrixOf[complex128]
+	err2 := json.Unmarshal(buf, &c2)
+	if err != nil || err2 != nil || !c2.ApproxEquals(c, 0.0) {
+		t.Errorf("Complex matrix JSON round trip error got= %s", string(buf))
+	}
+}
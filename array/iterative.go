@@ -0,0 +1,449 @@
+// iterative.go
+// Iterative solvers for sparse linear systems A.x = b, with A in CSR form.
+//
+// ConjugateGradient: for symmetric positive-definite A.
+// GMRES:             restarted GMRES(m) for general A.
+// BiCGSTAB:          for general A, with short recurrences.
+//
+// Each solver takes the incoming x as its initial guess, overwrites it
+// with the solution and returns the history of the relative residual
+// norm, ||b - A.x||/||b||, for the initial guess and after each iteration.
+// Preconditioning is applied on the right for GMRES and BiCGSTAB,
+// so that the reported residuals are those of the original system.
+// The references are:
+//     Y. Saad (2003) Iterative Methods for Sparse Linear Systems, 2nd ed.
+//     H.A. van der Vorst (1992) SIAM J. Sci. Stat. Comput. 13(2):631-644.
+//
+// PJ 2026-10-18
+
+package array
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// A preconditioner M approximates A and is cheap to invert.
+type Preconditioner interface {
+	// Solve M.z = r for z.
+	Apply(z, r *Vector) (*Vector, error)
+}
+
+type JacobiPreconditioner struct {
+	invDiag []float64
+}
+
+// The tolerance for a zero diagonal element may be given
+// as an optional SolverOptions value, as for the direct solvers.
+func NewJacobiPreconditioner(a *CSR, opts ...SolverOptions) (*JacobiPreconditioner, error) {
+	tiny := solverOptions(opts).pivotThreshold(a.normInf())
+	d := a.Diagonal()
+	p := JacobiPreconditioner{invDiag: make([]float64, len(d.Data))}
+	for i, dii := range d.Data {
+		if math.Abs(dii) <= tiny {
+			msg := fmt.Sprintf("Zero diagonal element at row %d", i)
+			return nil, errors.New(msg)
+		}
+		p.invDiag[i] = 1.0 / dii
+	}
+	return &p, nil
+}
+
+func (p *JacobiPreconditioner) Apply(z, r *Vector) (*Vector, error) {
+	n := len(p.invDiag)
+	if len(z.Data) != n || len(r.Data) != n {
+		msg := fmt.Sprintf("Inconsistent array lengths n:%v z:%v r:%v", n, len(z.Data), len(r.Data))
+		return z, errors.New(msg)
+	}
+	for i := 0; i < n; i++ {
+		z.Data[i] = p.invDiag[i] * r.Data[i]
+	}
+	return z, nil
+}
+
+// Incomplete LU factorisation with no fill-in, ILU(0).
+// The factors have the same sparsity pattern as A.
+type ILU0 struct {
+	lu   *CSR
+	diag []int // Position of the diagonal element in each row.
+}
+
+// The pivot tolerance may be given as an optional SolverOptions value.
+func NewILU0(a *CSR, opts ...SolverOptions) (*ILU0, error) {
+	if a.Rows != a.Cols {
+		msg := fmt.Sprintf("Matrix is not square: nrows=%d ncols=%d", a.Rows, a.Cols)
+		return nil, errors.New(msg)
+	}
+	tiny := solverOptions(opts).pivotThreshold(a.normInf())
+	n := a.Rows
+	lu := CSR{Rows: n, Cols: n, RowPtr: a.RowPtr, ColIdx: a.ColIdx,
+		Values: make([]float64, len(a.Values))}
+	copy(lu.Values, a.Values)
+	p := ILU0{lu: &lu, diag: make([]int, n)}
+	// Position, within the current row, of each column; -1 if absent.
+	iw := make([]int, n)
+	for j := 0; j < n; j++ {
+		iw[j] = -1
+	}
+	for i := 0; i < n; i++ {
+		lo, hi := lu.RowPtr[i], lu.RowPtr[i+1]
+		for k := lo; k < hi; k++ {
+			iw[lu.ColIdx[k]] = k
+		}
+		k := lo
+		for ; k < hi && lu.ColIdx[k] < i; k++ {
+			c := lu.ColIdx[k]
+			// Multiplier for row c, which has already been factored.
+			lu.Values[k] /= lu.Values[p.diag[c]]
+			lik := lu.Values[k]
+			for kk := p.diag[c] + 1; kk < lu.RowPtr[c+1]; kk++ {
+				if pos := iw[lu.ColIdx[kk]]; pos >= 0 {
+					lu.Values[pos] -= lik * lu.Values[kk]
+				}
+			}
+		}
+		if k >= hi || lu.ColIdx[k] != i || math.Abs(lu.Values[k]) <= tiny {
+			msg := fmt.Sprintf("Zero pivot in ILU(0) at row %d", i)
+			return nil, errors.New(msg)
+		}
+		p.diag[i] = k
+		for k := lo; k < hi; k++ {
+			iw[lu.ColIdx[k]] = -1
+		}
+	}
+	return &p, nil
+}
+
+func (p *ILU0) Apply(z, r *Vector) (*Vector, error) {
+	lu := p.lu
+	n := lu.Rows
+	if len(z.Data) != n || len(r.Data) != n {
+		msg := fmt.Sprintf("Inconsistent array lengths n:%v z:%v r:%v", n, len(z.Data), len(r.Data))
+		return z, errors.New(msg)
+	}
+	y := z.Data
+	copy(y, r.Data)
+	for i := 0; i < n; i++ {
+		s := y[i]
+		for k := lu.RowPtr[i]; k < p.diag[i]; k++ {
+			s -= lu.Values[k] * y[lu.ColIdx[k]]
+		}
+		y[i] = s
+	}
+	for i := n - 1; i >= 0; i-- {
+		s := y[i]
+		for k := p.diag[i] + 1; k < lu.RowPtr[i+1]; k++ {
+			s -= lu.Values[k] * y[lu.ColIdx[k]]
+		}
+		y[i] = s / lu.Values[p.diag[i]]
+	}
+	return z, nil
+}
+
+type IterativeOptions struct {
+	Tol     float64        // Stop when ||b - A.x|| <= Tol*||b||.
+	MaxIter int            // Limit on the number of iterations.
+	Restart int            // Krylov subspace size for GMRES(m).
+	Precond Preconditioner // May be nil, for no preconditioning.
+}
+
+func DefaultIterativeOptions() IterativeOptions {
+	return IterativeOptions{Tol: 1.0e-8, MaxIter: 1000, Restart: 30, Precond: nil}
+}
+
+type IterativeResult struct {
+	Iterations int
+	Residuals  []float64 // Relative residual norm history.
+	Converged  bool
+}
+
+func checkIterativeArgs(a *CSR, x, b *Vector) error {
+	if a.Rows != a.Cols {
+		msg := fmt.Sprintf("Matrix is not square: nrows=%d ncols=%d", a.Rows, a.Cols)
+		return errors.New(msg)
+	}
+	if len(x.Data) != a.Rows || len(b.Data) != a.Rows {
+		msg := fmt.Sprintf("Inconsistent dimensions a:%dx%d x:%v b:%v",
+			a.Rows, a.Cols, len(x.Data), len(b.Data))
+		return errors.New(msg)
+	}
+	return nil
+}
+
+// Apply the preconditioner, or just copy if there is none.
+func precondition(m Preconditioner, z, r *Vector) error {
+	if m == nil {
+		_, err := z.SetFromVector(*r)
+		return err
+	}
+	_, err := m.Apply(z, r)
+	return err
+}
+
+func notConverged(res IterativeResult) error {
+	msg := fmt.Sprintf("Did not converge in %d iterations, residual=%g",
+		res.Iterations, res.Residuals[len(res.Residuals)-1])
+	return errors.New(msg)
+}
+
+// Residual r = b - A.x and the norm that we measure it against.
+func residual(a *CSR, x, b, r *Vector) (float64, float64, error) {
+	_, err := r.MulCSR(a, x)
+	if err != nil {
+		return 0.0, 0.0, err
+	}
+	r.Sub(b, r)
+	bnorm := b.Mag()
+	if bnorm == 0.0 {
+		bnorm = 1.0
+	}
+	return r.Mag(), bnorm, nil
+}
+
+// Preconditioned conjugate gradient method for symmetric positive-definite A.
+// The preconditioner should also be symmetric positive definite.
+func ConjugateGradient(a *CSR, x, b *Vector, opts IterativeOptions) (IterativeResult, error) {
+	res := IterativeResult{}
+	err := checkIterativeArgs(a, x, b)
+	if err != nil {
+		return res, err
+	}
+	n := a.Rows
+	r := NewVector(n)
+	z := NewVector(n)
+	p := NewVector(n)
+	q := NewVector(n)
+	rnorm, bnorm, err := residual(a, x, b, r)
+	if err != nil {
+		return res, err
+	}
+	res.Residuals = append(res.Residuals, rnorm/bnorm)
+	if rnorm <= opts.Tol*bnorm {
+		res.Converged = true
+		return res, nil
+	}
+	if err = precondition(opts.Precond, z, r); err != nil {
+		return res, err
+	}
+	p.SetFromVector(*z)
+	rz, _ := VectorDot(r, z)
+	for res.Iterations < opts.MaxIter {
+		q.MulCSR(a, p)
+		pq, _ := VectorDot(p, q)
+		if pq <= 0.0 {
+			return res, errors.New("Matrix is not positive definite")
+		}
+		alpha := rz / pq
+		x.Blend(x, p, 1.0, alpha)
+		r.Blend(r, q, 1.0, -alpha)
+		res.Iterations++
+		rnorm = r.Mag()
+		res.Residuals = append(res.Residuals, rnorm/bnorm)
+		if rnorm <= opts.Tol*bnorm {
+			res.Converged = true
+			return res, nil
+		}
+		if err = precondition(opts.Precond, z, r); err != nil {
+			return res, err
+		}
+		rzNew, _ := VectorDot(r, z)
+		p.Blend(z, p, 1.0, rzNew/rz)
+		rz = rzNew
+	}
+	return res, notConverged(res)
+}
+
+// Restarted GMRES(m), with right preconditioning.
+// The Arnoldi process uses modified Gram-Schmidt and the small
+// least-squares problem is solved with Givens rotations as we go.
+func GMRES(a *CSR, x, b *Vector, opts IterativeOptions) (IterativeResult, error) {
+	res := IterativeResult{}
+	err := checkIterativeArgs(a, x, b)
+	if err != nil {
+		return res, err
+	}
+	n := a.Rows
+	m := opts.Restart
+	if m <= 0 {
+		m = min(30, n)
+	}
+	if m > n {
+		m = n
+	}
+	r := NewVector(n)
+	w := NewVector(n)
+	z := NewVector(n)
+	v := make([]*Vector, m+1)
+	for i := 0; i <= m; i++ {
+		v[i] = NewVector(n)
+	}
+	hm, _ := NewMatrix(m+1, m)
+	h := hm.Data
+	cs := make([]float64, m)
+	sn := make([]float64, m)
+	g := make([]float64, m+1)
+	y := make([]float64, m)
+	beta, bnorm, err := residual(a, x, b, r)
+	if err != nil {
+		return res, err
+	}
+	res.Residuals = append(res.Residuals, beta/bnorm)
+	if beta <= opts.Tol*bnorm {
+		res.Converged = true
+		return res, nil
+	}
+	for res.Iterations < opts.MaxIter {
+		v[0].SetFromVector(*r)
+		v[0].Scale(1.0 / beta)
+		for i := range g {
+			g[i] = 0.0
+		}
+		g[0] = beta
+		k := 0
+		for j := 0; j < m && res.Iterations < opts.MaxIter; j++ {
+			if err = precondition(opts.Precond, z, v[j]); err != nil {
+				return res, err
+			}
+			w.MulCSR(a, z)
+			for i := 0; i <= j; i++ {
+				h[i][j], _ = VectorDot(w, v[i])
+				w.Blend(w, v[i], 1.0, -h[i][j])
+			}
+			h[j+1][j] = w.Mag()
+			if h[j+1][j] != 0.0 {
+				v[j+1].SetFromVector(*w)
+				v[j+1].Scale(1.0 / h[j+1][j])
+			}
+			// Apply the previous rotations to the new column of H,
+			// then make a new rotation to eliminate h[j+1][j].
+			for i := 0; i < j; i++ {
+				hij := h[i][j]
+				h[i][j] = cs[i]*hij + sn[i]*h[i+1][j]
+				h[i+1][j] = -sn[i]*hij + cs[i]*h[i+1][j]
+			}
+			rho := math.Hypot(h[j][j], h[j+1][j])
+			if rho == 0.0 {
+				return res, errors.New("GMRES breakdown with zero Hessenberg column")
+			}
+			cs[j] = h[j][j] / rho
+			sn[j] = h[j+1][j] / rho
+			h[j][j] = rho
+			h[j+1][j] = 0.0
+			g[j+1] = -sn[j] * g[j]
+			g[j] = cs[j] * g[j]
+			res.Iterations++
+			k = j + 1
+			res.Residuals = append(res.Residuals, math.Abs(g[j+1])/bnorm)
+			if math.Abs(g[j+1]) <= opts.Tol*bnorm {
+				break
+			}
+		}
+		// Solve the upper-triangular system for the update coefficients.
+		for i := k - 1; i >= 0; i-- {
+			s := g[i]
+			for l := i + 1; l < k; l++ {
+				s -= h[i][l] * y[l]
+			}
+			y[i] = s / h[i][i]
+		}
+		w.SetFromScalar(0.0)
+		for i := 0; i < k; i++ {
+			w.Blend(w, v[i], 1.0, y[i])
+		}
+		if err = precondition(opts.Precond, z, w); err != nil {
+			return res, err
+		}
+		x.Add(x, z)
+		beta, _, err = residual(a, x, b, r)
+		if err != nil {
+			return res, err
+		}
+		if beta <= opts.Tol*bnorm {
+			// Report the true residual at convergence.
+			res.Residuals[len(res.Residuals)-1] = beta / bnorm
+			res.Converged = true
+			return res, nil
+		}
+	}
+	return res, notConverged(res)
+}
+
+// BiCGSTAB with right preconditioning.
+func BiCGSTAB(a *CSR, x, b *Vector, opts IterativeOptions) (IterativeResult, error) {
+	res := IterativeResult{}
+	err := checkIterativeArgs(a, x, b)
+	if err != nil {
+		return res, err
+	}
+	n := a.Rows
+	r := NewVector(n)
+	rnorm, bnorm, err := residual(a, x, b, r)
+	if err != nil {
+		return res, err
+	}
+	res.Residuals = append(res.Residuals, rnorm/bnorm)
+	if rnorm <= opts.Tol*bnorm {
+		res.Converged = true
+		return res, nil
+	}
+	rhat := r.Clone()
+	p := NewVector(n)
+	v := NewVector(n)
+	s := NewVector(n)
+	t := NewVector(n)
+	y := NewVector(n)
+	z := NewVector(n)
+	rho, alpha, omega := 1.0, 1.0, 1.0
+	for res.Iterations < opts.MaxIter {
+		rhoNew, _ := VectorDot(rhat, r)
+		if rhoNew == 0.0 {
+			return res, errors.New("BiCGSTAB breakdown with rho=0")
+		}
+		beta := (rhoNew / rho) * (alpha / omega)
+		// p = r + beta*(p - omega*v)
+		p.Blend(p, v, 1.0, -omega)
+		p.Blend(r, p, 1.0, beta)
+		if err = precondition(opts.Precond, y, p); err != nil {
+			return res, err
+		}
+		v.MulCSR(a, y)
+		rv, _ := VectorDot(rhat, v)
+		if rv == 0.0 {
+			return res, errors.New("BiCGSTAB breakdown with rhat.v=0")
+		}
+		alpha = rhoNew / rv
+		s.Blend(r, v, 1.0, -alpha)
+		res.Iterations++
+		if snorm := s.Mag(); snorm <= opts.Tol*bnorm {
+			x.Blend(x, y, 1.0, alpha)
+			res.Residuals = append(res.Residuals, snorm/bnorm)
+			res.Converged = true
+			return res, nil
+		}
+		if err = precondition(opts.Precond, z, s); err != nil {
+			return res, err
+		}
+		t.MulCSR(a, z)
+		ts, _ := VectorDot(t, s)
+		tt, _ := VectorDot(t, t)
+		if tt == 0.0 {
+			return res, errors.New("BiCGSTAB breakdown with t=0")
+		}
+		omega = ts / tt
+		x.Blend(x, y, 1.0, alpha)
+		x.Blend(x, z, 1.0, omega)
+		r.Blend(s, t, 1.0, -omega)
+		rnorm = r.Mag()
+		res.Residuals = append(res.Residuals, rnorm/bnorm)
+		if rnorm <= opts.Tol*bnorm {
+			res.Converged = true
+			return res, nil
+		}
+		if omega == 0.0 {
+			return res, errors.New("BiCGSTAB breakdown with omega=0")
+		}
+		rho = rhoNew
+	}
+	return res, notConverged(res)
+}
@@ -0,0 +1,157 @@
+// iterative_test.go
+// Try out the iterative solvers on 2-D model problems.
+// PJ 2026-10-18
+//
+
+package array
+
+import (
+	"testing"
+)
+
+// Five-point finite-difference operator on an m-by-m grid of interior
+// points, for -div(grad u) + c*du/dx. With c = 0 the matrix is SPD.
+func modelProblem(m int, c float64) *CSR {
+	n := m * m
+	coo, _ := NewCOO(n, n)
+	h := 1.0 / float64(m+1)
+	for j := 0; j < m; j++ {
+		for i := 0; i < m; i++ {
+			k := j*m + i
+			coo.Add(k, k, 4.0)
+			if i > 0 {
+				coo.Add(k, k-1, -1.0-0.5*c*h)
+			}
+			if i < m-1 {
+				coo.Add(k, k+1, -1.0+0.5*c*h)
+			}
+			if j > 0 {
+				coo.Add(k, k-m, -1.0)
+			}
+			if j < m-1 {
+				coo.Add(k, k+m, -1.0)
+			}
+		}
+	}
+	return coo.CSR()
+}
+
+func TestIterativeSolvers(t *testing.T) {
+	a := modelProblem(20, 0.0)
+	n := a.Rows
+	xref := NewVector(n)
+	for i := 0; i < n; i++ {
+		xref.Data[i] = 1.0 + float64(i%7)
+	}
+	b := NewVector(n)
+	b.MulCSR(a, xref)
+
+	opts := DefaultIterativeOptions()
+	opts.Tol = 1.0e-10
+	x := NewVector(n)
+	res, err := ConjugateGradient(a, x, b, opts)
+	if err != nil || !res.Converged || !x.ApproxEquals(xref, 1.0e-7) {
+		t.Errorf("CG failed, err=%v iterations=%d", err, res.Iterations)
+	}
+	if len(res.Residuals) != res.Iterations+1 || res.Residuals[0] != 1.0 {
+		t.Errorf("CG residual history has wrong form, len=%d", len(res.Residuals))
+	}
+	jacobi, _ := NewJacobiPreconditioner(a)
+	opts.Precond = jacobi
+	x.SetFromScalar(0.0)
+	res, err = ConjugateGradient(a, x, b, opts)
+	if err != nil || !x.ApproxEquals(xref, 1.0e-7) {
+		t.Errorf("Jacobi-preconditioned CG failed, err=%v iterations=%d", err, res.Iterations)
+	}
+	ilu, err := NewILU0(a)
+	if err != nil {
+		t.Fatalf("Failed ILU(0) factorisation, err: %s", err)
+	}
+	opts.Precond = ilu
+	x.SetFromScalar(0.0)
+	res, err = ConjugateGradient(a, x, b, opts)
+	if err != nil || !x.ApproxEquals(xref, 1.0e-7) {
+		t.Errorf("ILU(0)-preconditioned CG failed, err=%v iterations=%d", err, res.Iterations)
+	}
+	// A relative tolerance of 0.9 of the row-sum norm, 8, rejects the diagonal of 4.
+	_, err = NewJacobiPreconditioner(a, SolverOptions{RelTol: 0.9})
+	if err == nil {
+		t.Errorf("Jacobi preconditioner did not apply the relative tolerance.")
+	}
+	_, err = NewILU0(a, SolverOptions{RelTol: 0.9})
+	if err == nil {
+		t.Errorf("ILU(0) did not apply the relative tolerance.")
+	}
+	opts.Precond = nil
+	opts.MaxIter = 5
+	x.SetFromScalar(0.0)
+	res, err = ConjugateGradient(a, x, b, opts)
+	if err == nil || res.Converged || res.Iterations != 5 {
+		t.Errorf("CG should have reported failure to converge, iterations=%d", res.Iterations)
+	}
+
+	// Convection-diffusion makes the matrix unsymmetric.
+	a = modelProblem(20, 20.0)
+	b.MulCSR(a, xref)
+	opts = DefaultIterativeOptions()
+	opts.Tol = 1.0e-10
+	x.SetFromScalar(0.0)
+	res, err = GMRES(a, x, b, opts)
+	nPlain := res.Iterations
+	if err != nil || !res.Converged || !x.ApproxEquals(xref, 1.0e-7) {
+		t.Errorf("GMRES failed, err=%v iterations=%d", err, res.Iterations)
+	}
+	ilu, _ = NewILU0(a)
+	opts.Precond = ilu
+	x.SetFromScalar(0.0)
+	res, err = GMRES(a, x, b, opts)
+	if err != nil || !x.ApproxEquals(xref, 1.0e-7) {
+		t.Errorf("ILU(0)-preconditioned GMRES failed, err=%v iterations=%d", err, res.Iterations)
+	}
+	if res.Iterations >= nPlain {
+		t.Errorf("ILU(0) did not reduce GMRES iterations, %d vs %d", res.Iterations, nPlain)
+	}
+	opts.Precond = nil
+
+	// GMRES stagnates on the cyclic shift until the Krylov subspace is
+	// the whole space, so a requested Restart larger than n must give m = n.
+	ns := 40
+	coo, _ := NewCOO(ns, ns)
+	for i := 0; i < ns; i++ {
+		coo.Add((i+1)%ns, i, 1.0)
+	}
+	es := NewVector(ns)
+	es.Data[0] = 1.0
+	xs := NewVector(ns)
+	resS, err := GMRES(coo.CSR(), xs, es, IterativeOptions{Tol: 1.0e-10, MaxIter: 2 * ns, Restart: 100})
+	if err != nil || !resS.Converged || resS.Iterations != ns {
+		t.Errorf("GMRES with Restart > n failed, err=%v iterations=%d", err, resS.Iterations)
+	}
+	// A malformed matrix must be reported, not treated as zero.
+	bad := &CSR{Rows: ns, Cols: ns}
+	for _, solver := range []func(*CSR, *Vector, *Vector, IterativeOptions) (IterativeResult, error){
+		ConjugateGradient, GMRES, BiCGSTAB} {
+		xs.SetFromScalar(0.0)
+		_, err = solver(bad, xs, es, DefaultIterativeOptions())
+		if err == nil || err.Error() != "Empty or malformed CSR matrix" {
+			t.Errorf("Iterative solver did not report the malformed matrix, err=%v", err)
+		}
+	}
+
+	x.SetFromScalar(0.0)
+	res, err = BiCGSTAB(a, x, b, opts)
+	if err != nil || !res.Converged || !x.ApproxEquals(xref, 1.0e-7) {
+		t.Errorf("BiCGSTAB failed, err=%v iterations=%d", err, res.Iterations)
+	}
+	jacobi, _ = NewJacobiPreconditioner(a)
+	opts.Precond = jacobi
+	x.SetFromScalar(0.0)
+	res, err = BiCGSTAB(a, x, b, opts)
+	if err != nil || !x.ApproxEquals(xref, 1.0e-7) {
+		t.Errorf("Jacobi-preconditioned BiCGSTAB failed, err=%v iterations=%d", err, res.Iterations)
+	}
+	final := res.Residuals[len(res.Residuals)-1]
+	if final > 1.0e-10 {
+		t.Errorf("BiCGSTAB final residual=%g too large", final)
+	}
+}
@@ -0,0 +1,231 @@
+// json.go
+// Support for encoding/json, for Vector and Matrix and their generic forms.
+//
+// A Vector is encoded as a JSON array of numbers and a Matrix as an array
+// of row arrays, the same layout as produced by the String methods.
+// JSON has no representation for the non-finite values, so these are
+// encoded as the strings "NaN", "Infinity" and "-Infinity", which are
+// also the spellings used by JavaScript and by Python's json module.
+// Decoding accepts either numbers or those three strings.
+// For VectorOf and MatrixOf, a float32 element is written with the
+// shortest digits that round-trip at single precision, and a complex
+// element is written as the two-element array [re, im].
+//
+// PJ 2026-10-18
+
+package array
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+func appendJSONFloat(b []byte, x float64) []byte {
+	return appendJSONFloatBits(b, x, 64)
+}
+
+func appendJSONFloatBits(b []byte, x float64, bitSize int) []byte {
+	switch {
+	case math.IsNaN(x):
+		return append(b, `"NaN"`...)
+	case math.IsInf(x, 1):
+		return append(b, `"Infinity"`...)
+	case math.IsInf(x, -1):
+		return append(b, `"-Infinity"`...)
+	}
+	return strconv.AppendFloat(b, x, 'g', -1, bitSize)
+}
+
+func parseJSONFloat(raw json.RawMessage) (float64, error) {
+	if len(raw) > 0 && raw[0] == '"' {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return 0.0, err
+		}
+		switch s {
+		case "NaN":
+			return math.NaN(), nil
+		case "Infinity":
+			return math.Inf(1), nil
+		case "-Infinity":
+			return math.Inf(-1), nil
+		}
+		return 0.0, errors.New(fmt.Sprintf("Invalid string for a number: %q", s))
+	}
+	var x float64
+	if err := json.Unmarshal(raw, &x); err != nil {
+		return 0.0, errors.New(fmt.Sprintf("Invalid number: %s", string(raw)))
+	}
+	return x, nil
+}
+
+func (a Vector) MarshalJSON() ([]byte, error) {
+	return appendJSONArrayOf(nil, a.Data), nil
+}
+
+func (z *Vector) UnmarshalJSON(b []byte) error {
+	data, err := parseJSONArrayOf[float64](b)
+	if err != nil {
+		return err
+	}
+	z.Data = data
+	return nil
+}
+
+func (a Matrix) MarshalJSON() ([]byte, error) {
+	return appendJSONMatrixOf(nil, a.Data), nil
+}
+
+// Rows must all have the same length; an empty array gives an empty Matrix.
+func (z *Matrix) UnmarshalJSON(b []byte) error {
+	data, err := parseJSONMatrixOf[float64](b)
+	if err != nil {
+		return err
+	}
+	z.Data = data
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+// The encoding and decoding, shared by the float64 and generic forms.
+
+func appendJSONOf[T Number](b []byte, x T) []byte {
+	switch v := any(x).(type) {
+	case float32:
+		return appendJSONFloatBits(b, float64(v), 32)
+	case float64:
+		return appendJSONFloat(b, v)
+	case complex64:
+		b = append(b, '[')
+		b = appendJSONFloatBits(b, float64(real(v)), 32)
+		b = append(b, ',')
+		b = appendJSONFloatBits(b, float64(imag(v)), 32)
+		return append(b, ']')
+	case complex128:
+		b = append(b, '[')
+		b = appendJSONFloat(b, real(v))
+		b = append(b, ',')
+		b = appendJSONFloat(b, imag(v))
+		return append(b, ']')
+	}
+	return b
+}
+
+func appendJSONArrayOf[T Number](b []byte, data []T) []byte {
+	b = append(b, '[')
+	for i, x := range data {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendJSONOf(b, x)
+	}
+	return append(b, ']')
+}
+
+func parseJSONOf[T Number](raw json.RawMessage) (T, error) {
+	var z T
+	switch any(z).(type) {
+	case complex64, complex128:
+		var parts []json.RawMessage
+		if err := json.Unmarshal(raw, &parts); err != nil || len(parts) != 2 {
+			return z, errors.New(fmt.Sprintf("Invalid complex number: %s", string(raw)))
+		}
+		re, err := parseJSONFloat(parts[0])
+		if err != nil {
+			return z, err
+		}
+		im, err := parseJSONFloat(parts[1])
+		if err != nil {
+			return z, err
+		}
+		if _, ok := any(z).(complex64); ok {
+			return any(complex(float32(re), float32(im))).(T), nil
+		}
+		return any(complex(re, im)).(T), nil
+	}
+	x, err := parseJSONFloat(raw)
+	if err != nil {
+		return z, err
+	}
+	return fromFloat[T](x), nil
+}
+
+func parseJSONArrayOf[T Number](raw json.RawMessage) ([]T, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, err
+	}
+	data := make([]T, len(items))
+	for i, item := range items {
+		x, err := parseJSONOf[T](item)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Element %d: %s", i, err))
+		}
+		data[i] = x
+	}
+	return data, nil
+}
+
+func (a VectorOf[T]) MarshalJSON() ([]byte, error) {
+	return appendJSONArrayOf(nil, a.Data), nil
+}
+
+func (z *VectorOf[T]) UnmarshalJSON(b []byte) error {
+	data, err := parseJSONArrayOf[T](b)
+	if err != nil {
+		return err
+	}
+	z.Data = data
+	return nil
+}
+
+func appendJSONMatrixOf[T Number](b []byte, rows [][]T) []byte {
+	b = append(b, '[')
+	for i, row := range rows {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendJSONArrayOf(b, row)
+	}
+	return append(b, ']')
+}
+
+func parseJSONMatrixOf[T Number](raw json.RawMessage) ([][]T, error) {
+	var rows []json.RawMessage
+	if err := json.Unmarshal(raw, &rows); err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	data := make([][]T, len(rows))
+	for i, row := range rows {
+		r, err := parseJSONArrayOf[T](row)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Row %d: %s", i, err))
+		}
+		data[i] = r
+	}
+	m, err := NewMatrixOfFromArray(data)
+	if err != nil {
+		return nil, err
+	}
+	return m.Data, nil
+}
+
+func (a MatrixOf[T]) MarshalJSON() ([]byte, error) {
+	return appendJSONMatrixOf(nil, a.Data), nil
+}
+
+// As for Matrix, rows must all have the same length.
+func (z *MatrixOf[T]) UnmarshalJSON(b []byte) error {
+	data, err := parseJSONMatrixOf[T](b)
+	if err != nil {
+		return err
+	}
+	z.Data = data
+	return nil
+}
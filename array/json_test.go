@@ -0,0 +1,68 @@
+// json_test.go
+// Try out the JSON encoding and decoding of Vector and Matrix.
+// PJ 2026-10-18
+//
+
+package array
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestVectorJSON(t *testing.T) {
+	v1 := NewVectorFromArray([]float64{1.5, -2.0, 1.0e20, math.NaN(), math.Inf(1), math.Inf(-1)})
+	b, err := json.Marshal(v1)
+	want := `[1.5,-2,1e+20,"NaN","Infinity","-Infinity"]`
+	if err != nil || string(b) != want {
+		t.Errorf("Vector JSON encoding error got= %s want= %s", string(b), want)
+	}
+	if !json.Valid(b) {
+		t.Errorf("Vector JSON encoding is not valid JSON: %s", string(b))
+	}
+	var v2 Vector
+	err = json.Unmarshal(b, &v2)
+	if err != nil || len(v2.Data) != 6 || v2.Data[0] != 1.5 || !math.IsNaN(v2.Data[3]) ||
+		!math.IsInf(v2.Data[4], 1) || !math.IsInf(v2.Data[5], -1) {
+		t.Errorf("Vector JSON decoding error v2= %s err= %v", v2.String(), err)
+	}
+	// As a field within a larger structure.
+	type record struct {
+		Name string
+		X    *Vector
+	}
+	b, _ = json.Marshal(record{Name: "a", X: NewVectorFromArray([]float64{1.0, 2.0})})
+	var r record
+	err = json.Unmarshal(b, &r)
+	if err != nil || r.X == nil || r.X.Data[1] != 2.0 {
+		t.Errorf("Vector JSON field error got= %s err= %v", string(b), err)
+	}
+	err = json.Unmarshal([]byte(`[1.0, "one"]`), &v2)
+	if err == nil {
+		t.Errorf("Did not detect invalid element.")
+	}
+}
+
+func TestMatrixJSON(t *testing.T) {
+	m1, _ := NewMatrixFromArray([][]float64{{1.0, math.Inf(1)}, {3.0, 4.0}})
+	b, err := json.Marshal(m1)
+	want := `[[1,"Infinity"],[3,4]]`
+	if err != nil || string(b) != want {
+		t.Errorf("Matrix JSON encoding error got= %s want= %s", string(b), want)
+	}
+	var m2 Matrix
+	err = json.Unmarshal(b, &m2)
+	if err != nil || m2.Data[1][1] != 4.0 || !math.IsInf(m2.Data[0][1], 1) {
+		t.Errorf("Matrix JSON decoding error m2= %s err= %v", m2.String(), err)
+	}
+	err = json.Unmarshal([]byte(`[[1, 2], [3]]`), &m2)
+	if err == nil {
+		t.Errorf("Did not detect ragged rows.")
+	}
+	var m3 Matrix
+	err = json.Unmarshal([]byte(`[]`), &m3)
+	if err != nil || !m3.IsEmpty() {
+		t.Errorf("Empty matrix JSON decoding error m3= %s err= %v", m3.String(), err)
+	}
+}
@@ -0,0 +1,190 @@
+// lu.go
+// LU decomposition, with partial pivoting, of a square Matrix.
+//
+// Factor once with NewLU and then solve for as many right-hand sides
+// as required, without rebuilding an augmented matrix each time.
+// As for GaussJordanElimination, the pivot tolerance may be given
+// as an optional SolverOptions value.
+//
+// PJ 2026-10-18
+
+package array
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+type LU struct {
+	// Combined factors: the unit lower-triangular L is stored below
+	// the diagonal and the upper-triangular U is stored on and above it.
+	LU *Matrix
+	// Row permutation: row i of L.U corresponds to row Perm[i] of A.
+	Perm []int
+	// +1 or -1 for an even or odd number of row interchanges.
+	Sign float64
+	// Ratio of the largest magnitude in U to the largest magnitude in A.
+	// Large values indicate that the factorisation may be inaccurate.
+	PivotGrowth float64
+}
+
+// Factor the square matrix a such that P.A = L.U
+// The matrix a is not altered.
+func NewLU(a *Matrix, opts ...SolverOptions) (*LU, error) {
+	n := len(a.Data)
+	if n == 0 {
+		return nil, errors.New("Empty Matrix")
+	}
+	if len(a.Data[0]) != n {
+		msg := fmt.Sprintf("Matrix is not square: nrows=%d ncols=%d", n, len(a.Data[0]))
+		return nil, errors.New(msg)
+	}
+	lu, err := NewMatrixFromArray(a.Data)
+	if err != nil {
+		return nil, err
+	}
+	f := LU{LU: lu, Perm: make([]int, n), Sign: 1.0}
+	for i := 0; i < n; i++ {
+		f.Perm[i] = i
+	}
+	maxA := 0.0
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			maxA = math.Max(maxA, math.Abs(lu.Data[i][j]))
+		}
+	}
+	tiny := solverOptions(opts).pivotThreshold(rowSumScale(a.Data, n))
+	c := lu.Data
+	for j := 0; j < n; j++ {
+		// Select pivot, the largest magnitude in column j.
+		p := j
+		for i := j + 1; i < n; i++ {
+			if math.Abs(c[i][j]) > math.Abs(c[p][j]) {
+				p = i
+			}
+		}
+		if math.Abs(c[p][j]) <= tiny {
+			return nil, errors.New(fmt.Sprintf("Singular with pivot=%v", c[p][j]))
+		}
+		if p != j {
+			c[p], c[j] = c[j], c[p]
+			f.Perm[p], f.Perm[j] = f.Perm[j], f.Perm[p]
+			f.Sign = -f.Sign
+		}
+		// Eliminate below the diagonal, keeping the multipliers in place.
+		cjj := c[j][j]
+		for i := j + 1; i < n; i++ {
+			c[i][j] /= cjj
+			lij := c[i][j]
+			if lij == 0.0 {
+				continue
+			}
+			for col := j + 1; col < n; col++ {
+				c[i][col] -= lij * c[j][col]
+			}
+		}
+	}
+	maxU := 0.0
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			maxU = math.Max(maxU, math.Abs(c[i][j]))
+		}
+	}
+	f.PivotGrowth = maxU / maxA
+	return &f, nil
+}
+
+// Solve A.x = b for x, using the previously computed factors.
+// The vectors x and b may be the same.
+func (f *LU) Solve(x, b *Vector) (*Vector, error) {
+	n := len(f.Perm)
+	if len(x.Data) != n || len(b.Data) != n {
+		msg := fmt.Sprintf("Inconsistent array lengths n:%v x:%v b:%v",
+			n, len(x.Data), len(b.Data))
+		return x, errors.New(msg)
+	}
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		y[i] = b.Data[f.Perm[i]]
+	}
+	f.substitute(y)
+	copy(x.Data, y)
+	return x, nil
+}
+
+// Solve A.X = B for the columns of X, using the previously computed factors.
+// The matrices X and B may be the same.
+func (f *LU) SolveMatrix(x, b *Matrix) (*Matrix, error) {
+	n := len(f.Perm)
+	if len(x.Data) != n || len(b.Data) != n {
+		msg := fmt.Sprintf("Inconsistent matrix rows n:%v x:%v b:%v",
+			n, len(x.Data), len(b.Data))
+		return x, errors.New(msg)
+	}
+	ncols := len(b.Data[0])
+	if len(x.Data[0]) != ncols {
+		msg := fmt.Sprintf("Inconsistent matrix columns x:%v b:%v",
+			len(x.Data[0]), ncols)
+		return x, errors.New(msg)
+	}
+	y := make([]float64, n)
+	z := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		z[i] = make([]float64, ncols)
+	}
+	for j := 0; j < ncols; j++ {
+		for i := 0; i < n; i++ {
+			y[i] = b.Data[f.Perm[i]][j]
+		}
+		f.substitute(y)
+		for i := 0; i < n; i++ {
+			z[i][j] = y[i]
+		}
+	}
+	for i := 0; i < n; i++ {
+		copy(x.Data[i], z[i])
+	}
+	return x, nil
+}
+
+// Forward and back substitution, in place, on an already-permuted vector.
+func (f *LU) substitute(y []float64) {
+	n := len(y)
+	c := f.LU.Data
+	for i := 1; i < n; i++ {
+		s := y[i]
+		for k := 0; k < i; k++ {
+			s -= c[i][k] * y[k]
+		}
+		y[i] = s
+	}
+	for i := n - 1; i >= 0; i-- {
+		s := y[i]
+		for k := i + 1; k < n; k++ {
+			s -= c[i][k] * y[k]
+		}
+		y[i] = s / c[i][i]
+	}
+}
+
+func (f *LU) Det() float64 {
+	det := f.Sign
+	for i := 0; i < len(f.Perm); i++ {
+		det *= f.LU.Data[i][i]
+	}
+	return det
+}
+
+// Construct the inverse of A from the factors.
+func (f *LU) Inverse() (*Matrix, error) {
+	n := len(f.Perm)
+	inv, err := NewMatrix(n, n)
+	if err != nil {
+		return inv, err
+	}
+	for i := 0; i < n; i++ {
+		inv.Data[i][i] = 1.0
+	}
+	return f.SolveMatrix(inv, inv)
+}
@@ -0,0 +1,88 @@
+// lu_test.go
+// Try out the LU decomposition.
+// PJ 2026-10-18
+//
+
+package array
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLU(t *testing.T) {
+	a, _ := NewMatrixFromArray([][]float64{{0.0, 2.0, 0.0, 1.0},
+		{2.0, 2.0, 3.0, 2.0},
+		{4.0, -3.0, 0.0, 1.0},
+		{6.0, 1.0, -6.0, -5.0}})
+	f, err := NewLU(a)
+	if err != nil {
+		t.Fatalf("Failed to factor a, err: %s", err)
+	}
+	// The original matrix should not have been altered.
+	if a.Data[0][0] != 0.0 || a.Data[3][0] != 6.0 {
+		t.Errorf("Factorisation altered a=%s", a.String())
+	}
+	b := NewVectorFromArray([]float64{0.0, -2.0, -7.0, 6.0})
+	x := NewVector(4)
+	_, err = f.Solve(x, b)
+	xref := NewVectorFromArray([]float64{-0.5, 1.0, 1.0/3.0, -2.0})
+	if err != nil || !x.ApproxEquals(xref, 1.0e-9) {
+		t.Errorf("LU solve error x=%s want=%s", x.String(), xref.String())
+	}
+	// Reuse the factors for another right-hand side, in place.
+	b2 := NewVectorFromArray([]float64{3.0, 9.0, 2.0, -4.0})
+	_, err = f.Solve(b2, b2)
+	xref2 := NewVectorFromArray([]float64{1.0, 1.0, 1.0, 1.0})
+	if err != nil || !b2.ApproxEquals(xref2, 1.0e-9) {
+		t.Errorf("LU solve in place error x=%s want=%s", b2.String(), xref2.String())
+	}
+	_, err = f.Solve(NewVector(3), b)
+	if err == nil {
+		t.Errorf("LU solve should have detected mismatch in lengths.")
+	}
+	det := f.Det()
+	if math.Abs(det + 234.0) > 1.0e-9 {
+		t.Errorf("LU determinant error det=%g want=-234.0", det)
+	}
+	if f.PivotGrowth < 1.0 {
+		t.Errorf("Implausible pivot growth=%g", f.PivotGrowth)
+	}
+	inv, err := f.Inverse()
+	if err != nil {
+		t.Errorf("Failed to compute inverse, err: %s", err)
+	}
+	// Check that A.inv(A) is the identity matrix.
+	prod, _ := NewMatrix(4, 4)
+	eye, _ := NewMatrix(4, 4)
+	for i := 0; i < 4; i++ {
+		eye.Data[i][i] = 1.0
+		for j := 0; j < 4; j++ {
+			for k := 0; k < 4; k++ {
+				prod.Data[i][j] += a.Data[i][k] * inv.Data[k][j]
+			}
+		}
+	}
+	if !prod.ApproxEquals(eye, 1.0e-9) {
+		t.Errorf("Incorrect inverse, a.inv=%s", prod.String())
+	}
+	// Several right-hand sides at once.
+	bm, _ := NewMatrixFromArray([][]float64{{0.0, 3.0}, {-2.0, 9.0}, {-7.0, 2.0}, {6.0, -4.0}})
+	xm, _ := NewMatrix(4, 2)
+	_, err = f.SolveMatrix(xm, bm)
+	xmref, _ := NewMatrixFromArray([][]float64{{-0.5, 1.0}, {1.0, 1.0}, {1.0/3.0, 1.0}, {-2.0, 1.0}})
+	if err != nil || !xm.ApproxEquals(xmref, 1.0e-9) {
+		t.Errorf("LU solve matrix error x=%s want=%s", xm.String(), xmref.String())
+	}
+
+	s, _ := NewMatrixFromArray([][]float64{{1.0, 2.0}, {2.0, 4.0}})
+	_, err = NewLU(s)
+	if err == nil {
+		t.Errorf("Did not detect singular matrix s=%s", s.String())
+	}
+	r, _ := NewMatrixFromArray([][]float64{{1.0, 2.0, 3.0}, {2.0, 4.0, 5.0}})
+	_, err = NewLU(r)
+	if err == nil {
+		t.Errorf("Did not detect non-square matrix r=%s", r.String())
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"errors"
 	"math"
 	"bytes"
+	"sync/atomic"
 )
 
 type Matrix struct {
@@ -35,8 +36,10 @@ func NewMatrix(nrows, ncols int) (*Matrix, error) {
 		msg := fmt.Sprintf("Invalid value for ncols=%v", ncols)
 		return &z, errors.New(msg)
 	}
+	// The rows share a single backing store, for better cache behaviour.
+	store := make([]float64, nrows*ncols)
 	for i := 0; i < nrows; i++ {
-		z.Data[i] = make([]float64, ncols)
+		z.Data[i] = store[i*ncols : (i+1)*ncols : (i+1)*ncols]
 	}
 	return &z, nil
 }
@@ -52,13 +55,14 @@ func NewMatrixFromArray(data [][]float64) (*Matrix, error) {
 	if ncols0 == 0 {
 		return &z, errors.New("Zero columns")
 	}
+	store := make([]float64, nrows*ncols0)
 	for i := 0; i < nrows; i++ {
 		ncols := len(data[i])
 		if ncols != ncols0 {
 			msg := fmt.Sprintf("Ragged rows: ncols0=%d ncols[%d]=%d", ncols0, i, ncols)
 			return &z, errors.New(msg)
 		}
-		z.Data[i] = make([]float64, ncols0)
+		z.Data[i] = store[i*ncols0 : (i+1)*ncols0 : (i+1)*ncols0]
 		for j := 0; j < ncols; j++ {
 			z.Data[i][j] = data[i][j]
 		}
@@ -130,65 +134,197 @@ func (a *Matrix) NormInf() float64 {
 	return norm
 }
 
-var verySmallValue float64 = 1.0e-16
+func (a *Matrix) Dims() (int, int) {
+	nrows := len(a.Data)
+	if nrows == 0 {
+		return 0, 0
+	}
+	return nrows, len(a.Data[0])
+}
 
-func SetVerySmallValue(v float64) {
-	verySmallValue = v
-	return
+func NewIdentityMatrix(n int) (*Matrix, error) {
+	z, err := NewMatrix(n, n)
+	if err != nil {
+		return z, err
+	}
+	for i := 0; i < n; i++ {
+		z.Data[i][i] = 1.0
+	}
+	return z, nil
 }
 
-// Perform Gauss-Jordan elimination on an augmented matrix.
-// c = [A|b] such that the mutated matrix becomes [I|x]
-// where x is the solution vector(s) to A.x = b
-// When computing an inverse, the incoming data is assumed to be c=[A|I].
-func (c *Matrix) GaussJordanElimination() (*Matrix, error) {
-	nrows := len(c.Data)
-	if nrows == 0 {
-		return c, errors.New("Empty Matrix")
+func (a *Matrix) Clone() *Matrix {
+	nrows := len(a.Data)
+	z := Matrix{Data: make([][]float64, nrows)}
+	size := 0
+	for i := 0; i < nrows; i++ {
+		size += len(a.Data[i])
 	}
-	ncols := len(c.Data[0])
-	if ncols == 0 {
-		return c, errors.New("Empty rows in Matrix")
+	// One backing store, as for NewMatrix.
+	store := make([]float64, size)
+	start := 0
+	for i := 0; i < nrows; i++ {
+		end := start + len(a.Data[i])
+		z.Data[i] = store[start:end:end]
+		copy(z.Data[i], a.Data[i])
+		start = end
 	}
-	if nrows == 1 {
-		det := c.Data[0][0]
-		if math.Abs(det) < verySmallValue {
-			return c, errors.New(fmt.Sprintf("Singular with det=%v", det))
-		}
+	return &z
+}
+
+// Returns true if any row of one matrix overlaps any row of the other,
+// as for views into the same Dense storage.
+func (a *Matrix) aliases(b *Matrix) bool {
+	return a == b || aliasesOf(a.Data, b.Data)
+}
+
+func dimsError(z, a, b *Matrix) error {
+	if b == nil {
+		return dimsErrorOf(z.Data, a.Data)
+	}
+	return dimsErrorOf(z.Data, a.Data, b.Data)
+}
+
+// The arithmetic functions follow the conventions of Vector,
+// with results going into a pre-allocated receiver of the correct size.
+// Add, Sub, Scale and SetFromMatrix allow their arguments to alias the
+// receiver, so z = z + a can be obtained as z.Add(z,a).
+// Mul and MulVec need separate storage for their result and will return
+// an error if the receiver aliases an argument.
+// Transpose may be done in place only for a square matrix.
+
+func (z *Matrix) SetFromMatrix(a *Matrix) (*Matrix, error) {
+	nrows, ncols := z.Dims()
+	ar, ac := a.Dims()
+	if nrows != ar || ncols != ac {
+		return z, dimsError(z, a, nil)
+	}
+	for i := 0; i < nrows; i++ {
+		copy(z.Data[i], a.Data[i])
+	}
+	return z, nil
+}
+
+func (z *Matrix) Add(a, b *Matrix) (*Matrix, error) {
+	nrows, ncols := z.Dims()
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if nrows != ar || nrows != br || ncols != ac || ncols != bc {
+		return z, dimsError(z, a, b)
+	}
+	for i := 0; i < nrows; i++ {
 		for j := 0; j < ncols; j++ {
-			c.Data[0][j] /= det
-		}
-		return c, nil
-	}
-	for j := 0; j < nrows; j++ {
-		// Select pivot, the largest magnitude in column j.
-		p := j
-		for i := j+1; i < nrows; i++ {
-			if math.Abs(c.Data[i][j]) > math.Abs(c.Data[p][j]) {
-				p = i
-			}
+			z.Data[i][j] = a.Data[i][j] + b.Data[i][j]
 		}
-		if math.Abs(c.Data[p][j]) < verySmallValue {
-			return c, errors.New(fmt.Sprintf("Singular with pivot=%v", c.Data[p][j]))
-		}
-		if p != j {
-			// Swap rows, to get pivot onto the diagonal.
-			c.Data[p], c.Data[j] = c.Data[j], c.Data[p]
+	}
+	return z, nil
+}
+
+func (z *Matrix) Sub(a, b *Matrix) (*Matrix, error) {
+	nrows, ncols := z.Dims()
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if nrows != ar || nrows != br || ncols != ac || ncols != bc {
+		return z, dimsError(z, a, b)
+	}
+	for i := 0; i < nrows; i++ {
+		for j := 0; j < ncols; j++ {
+			z.Data[i][j] = a.Data[i][j] - b.Data[i][j]
 		}
-		// Scale row j to get unity on the diagonal.
-		cjj := c.Data[j][j]
-		for col := 0; col < ncols; col++ {
-			c.Data[j][col] /= cjj
+	}
+	return z, nil
+}
+
+func (z *Matrix) Scale(s float64) *Matrix {
+	for i := 0; i < len(z.Data); i++ {
+		for j := 0; j < len(z.Data[i]); j++ {
+			z.Data[i][j] *= s
 		}
-		// Do the elimination to get zeros in all off-diagonal values in column j.
-		for i := 0; i < nrows; i++ {
-			if i == j { continue; }
-			cij := c.Data[i][j]
-			for col := 0; col < ncols; col++ {
-				c.Data[i][col] -= cij * c.Data[j][col]
-			}
+	}
+	return z
+}
+
+// Matrix product z = a.b
+func (z *Matrix) Mul(a, b *Matrix) (*Matrix, error) {
+	return z, mulOf(z.Data, a.Data, b.Data)
+}
+
+func (z *Matrix) Transpose(a *Matrix) (*Matrix, error) {
+	return z, transposeOf(z.Data, a.Data)
+}
+
+// Matrix-vector product y = a.x
+func (y *Vector) MulVec(a *Matrix, x *Vector) (*Vector, error) {
+	return y, mulVecOf(y.Data, a.Data, x.Data)
+}
+
+// The default threshold below which a pivot is deemed too small.
+// It is held atomically so that SetVerySmallValue does not race with
+// solves in other goroutines. To solve different-scale systems
+// concurrently, pass SolverOptions to each solve instead.
+var verySmallValueBits atomic.Uint64
+
+func init() {
+	verySmallValueBits.Store(math.Float64bits(1.0e-16))
+}
+
+func SetVerySmallValue(v float64) {
+	verySmallValueBits.Store(math.Float64bits(v))
+	return
+}
+
+func VerySmallValue() float64 {
+	return math.Float64frombits(verySmallValueBits.Load())
+}
+
+// Pivot tolerances for the direct solvers and factorisations.
+// A pivot is deemed too small if its magnitude is not greater than
+// max(AbsTol, RelTol*scale), where scale is the infinity norm
+// of the coefficient matrix. An exactly zero pivot is always rejected,
+// even by the zero-value SolverOptions{}.
+type SolverOptions struct {
+	AbsTol float64
+	RelTol float64
+}
+
+// The absolute tolerance is taken from the package-level default,
+// as set by SetVerySmallValue, and there is no relative tolerance.
+func DefaultSolverOptions() SolverOptions {
+	return SolverOptions{AbsTol: VerySmallValue(), RelTol: 0.0}
+}
+
+func (o SolverOptions) pivotThreshold(scale float64) float64 {
+	return math.Max(o.AbsTol, o.RelTol*scale)
+}
+
+// The solvers accept options as an optional trailing argument,
+// so that existing calls continue to use the package-level default.
+func solverOptions(opts []SolverOptions) SolverOptions {
+	if len(opts) > 0 {
+		return opts[0]
+	}
+	return DefaultSolverOptions()
+}
+
+// Infinity norm of the leading ncols columns of the rows of c.
+func rowSumScale(c [][]float64, ncols int) float64 {
+	scale := 0.0
+	for i := 0; i < len(c); i++ {
+		rowsum := 0.0
+		for j := 0; j < ncols && j < len(c[i]); j++ {
+			rowsum += math.Abs(c[i][j])
 		}
+		scale = math.Max(scale, rowsum)
 	}
-	return c, nil
+	return scale
+}
+
+// Perform Gauss-Jordan elimination on an augmented matrix.
+// c = [A|b] such that the mutated matrix becomes [I|x]
+// where x is the solution vector(s) to A.x = b
+// When computing an inverse, the incoming data is assumed to be c=[A|I].
+// The pivot tolerance may be given as an optional SolverOptions value.
+func (c *Matrix) GaussJordanElimination(opts ...SolverOptions) (*Matrix, error) {
+	return c, gaussJordanOf(c.Data, opts)
 }
 
@@ -9,6 +9,7 @@ import (
 	"testing"
 	_ "fmt"
 	"math"
+	"sync"
 )
 
 func TestMatrix(t *testing.T) {
@@ -53,3 +54,176 @@ func TestMatrix(t *testing.T) {
 		t.Errorf("Did not detect singular matrix m6=%v", m6)
 	}
 }
+
+func TestMatrixArithmetic(t *testing.T) {
+	a, _ := NewMatrixFromArray([][]float64{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}})
+	b, _ := NewMatrixFromArray([][]float64{{7.0, 8.0}, {9.0, 10.0}, {11.0, 12.0}})
+	c, _ := NewMatrix(2, 2)
+	_, err := c.Mul(a, b)
+	cref, _ := NewMatrixFromArray([][]float64{{58.0, 64.0}, {139.0, 154.0}})
+	if err != nil || !c.ApproxEquals(cref, 1.0e-9) {
+		t.Errorf("Matrix Mul error c=%s want=%s", c.String(), cref.String())
+	}
+	_, err = c.Mul(a, a)
+	if err == nil {
+		t.Errorf("Matrix Mul should have detected mismatch in dimensions.")
+	}
+	_, err = c.Mul(c, cref)
+	if err == nil {
+		t.Errorf("Matrix Mul should have detected aliasing of result.")
+	}
+	at, _ := NewMatrix(3, 2)
+	at.Transpose(a)
+	d, _ := NewMatrix(3, 2)
+	d.Add(at, b)
+	dref, _ := NewMatrixFromArray([][]float64{{8.0, 12.0}, {11.0, 15.0}, {14.0, 18.0}})
+	if !d.ApproxEquals(dref, 1.0e-9) {
+		t.Errorf("Matrix Transpose and Add error d=%s want=%s", d.String(), dref.String())
+	}
+	d.Sub(d, b)
+	if !d.ApproxEquals(at, 1.0e-9) {
+		t.Errorf("Matrix Sub error d=%s want=%s", d.String(), at.String())
+	}
+	_, err = d.Add(a, b)
+	if err == nil {
+		t.Errorf("Matrix Add should have detected mismatch in dimensions.")
+	}
+	// In-place transpose of a square matrix.
+	c.Transpose(c)
+	c.Scale(2.0)
+	cref2, _ := NewMatrixFromArray([][]float64{{116.0, 278.0}, {128.0, 308.0}})
+	if !c.ApproxEquals(cref2, 1.0e-9) {
+		t.Errorf("Matrix Transpose in place error c=%s want=%s", c.String(), cref2.String())
+	}
+	e := c.Clone()
+	eye, _ := NewIdentityMatrix(2)
+	c.Mul(e, eye)
+	if !c.ApproxEquals(e, 1.0e-9) {
+		t.Errorf("Matrix Clone error e=%s want=%s", e.String(), c.String())
+	}
+	x := NewVectorFromArray([]float64{1.0, 1.0, 1.0})
+	y := NewVector(2)
+	_, err = y.MulVec(a, x)
+	yref := NewVectorFromArray([]float64{6.0, 15.0})
+	if err != nil || !y.ApproxEquals(yref, 1.0e-9) {
+		t.Errorf("Matrix MulVec error y=%s want=%s", y.String(), yref.String())
+	}
+	_, err = x.MulVec(a, x)
+	if err == nil {
+		t.Errorf("MulVec should have detected mismatch in dimensions.")
+	}
+	// Overlapping but not identical storage.
+	w := NewVectorFromArray([]float64{1.0, 1.0, 1.0, 1.0})
+	y2 := &Vector{Data: w.Data[1:3]}
+	x2 := &Vector{Data: w.Data[0:3]}
+	_, err = y2.MulVec(a, x2)
+	if err == nil {
+		t.Errorf("MulVec should have detected overlap of result with x.")
+	}
+	dn, _ := NewDense(3, 2)
+	v := dn.Matrix()
+	z2 := &Matrix{Data: v.Data[1:3]}
+	a2 := &Matrix{Data: v.Data[0:2]}
+	_, err = z2.Mul(a2, eye)
+	if err == nil {
+		t.Errorf("Matrix Mul should have detected overlapping Dense views.")
+	}
+}
+
+func TestSolverOptions(t *testing.T) {
+	// A well-conditioned matrix at a tiny scale is rejected by the default
+	// absolute tolerance but accepted with a scale-relative tolerance.
+	data := [][]float64{{2.0e-20, 1.0e-20, 3.0e-20}, {1.0e-20, 3.0e-20, 4.0e-20}}
+	m1, _ := NewMatrixFromArray(data)
+	_, err := m1.GaussJordanElimination()
+	if err == nil {
+		t.Errorf("Default tolerance should have rejected tiny pivots m1=%s", m1.String())
+	}
+	rel := SolverOptions{AbsTol: 0.0, RelTol: 1.0e-12}
+	m1, _ = NewMatrixFromArray(data)
+	_, err = m1.GaussJordanElimination(rel)
+	m1ref, _ := NewMatrixFromArray([][]float64{{1.0, 0.0, 1.0}, {0.0, 1.0, 1.0}})
+	if err != nil || !m1.ApproxEquals(m1ref, 1.0e-9) {
+		t.Errorf("Relative tolerance elimination error m1=%s err=%v", m1.String(), err)
+	}
+	a, _ := NewMatrixFromArray([][]float64{{2.0e-20, 1.0e-20}, {1.0e-20, 3.0e-20}})
+	_, err = NewLU(a, rel)
+	if err != nil {
+		t.Errorf("Relative tolerance LU failed, err: %s", err)
+	}
+	_, err = NewCholesky(a, rel)
+	if err != nil {
+		t.Errorf("Relative tolerance Cholesky failed, err: %s", err)
+	}
+	// A nearly-singular matrix passes the default test but not a
+	// demanding relative test.
+	b, _ := NewMatrixFromArray([][]float64{{1.0, 1.0}, {1.0, 1.0+1.0e-13}})
+	_, err = NewLU(b)
+	if err != nil {
+		t.Errorf("Default tolerance LU failed, err: %s", err)
+	}
+	_, err = NewLU(b, SolverOptions{AbsTol: 0.0, RelTol: 1.0e-10})
+	if err == nil {
+		t.Errorf("Relative tolerance should have rejected nearly-singular b=%s", b.String())
+	}
+	// Zero tolerances still reject exactly singular matrices.
+	zero := SolverOptions{}
+	c, _ := NewMatrixFromArray([][]float64{{0.0, 1.0}, {0.0, 1.0}})
+	_, err = NewLU(c, zero)
+	if err == nil {
+		t.Errorf("Zero tolerance LU should have rejected singular c=%s", c.String())
+	}
+	c, _ = NewMatrixFromArray([][]float64{{1.0, 2.0, 3.0}, {2.0, 4.0, 6.0}})
+	_, err = c.GaussJordanElimination(zero)
+	if err == nil {
+		t.Errorf("Zero tolerance elimination should have rejected rank-1 c=%s", c.String())
+	}
+	c, _ = NewMatrixFromArray([][]float64{{1.0, 1.0}, {1.0, 1.0}})
+	_, err = NewCholesky(c, zero)
+	if err == nil {
+		t.Errorf("Zero tolerance Cholesky should have rejected semidefinite c=%s", c.String())
+	}
+	_, err = NewLDLT(c, zero)
+	if err == nil {
+		t.Errorf("Zero tolerance LDLT should have rejected singular c=%s", c.String())
+	}
+	_, err = NewLU(c, SolverOptions{RelTol: 1.0e-12})
+	if err == nil {
+		t.Errorf("Relative tolerance LU should have rejected singular c=%s", c.String())
+	}
+	zm, _ := NewMatrix(2, 2)
+	_, err = NewLU(zm, SolverOptions{RelTol: 1.0e-12})
+	if err == nil {
+		t.Errorf("Relative tolerance LU should have rejected the zero matrix.")
+	}
+	// The package-level value remains the default.
+	SetVerySmallValue(1.0e-30)
+	m1, _ = NewMatrixFromArray(data)
+	_, err = m1.GaussJordanElimination()
+	SetVerySmallValue(1.0e-16)
+	if err != nil {
+		t.Errorf("Adjusted default tolerance elimination failed, err: %s", err)
+	}
+	// Concurrent solves with different tolerances.
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for k := 0; k < 8; k++ {
+		wg.Add(1)
+		go func(k int) {
+			defer wg.Done()
+			s := math.Pow(10.0, -float64(4*k))
+			c, _ := NewMatrixFromArray([][]float64{{2.0*s, 1.0*s, 3.0*s}, {1.0*s, 3.0*s, 4.0*s}})
+			opts := SolverOptions{AbsTol: 1.0e-3*s, RelTol: 0.0}
+			_, errs[k] = c.GaussJordanElimination(opts)
+			if errs[k] == nil && !c.ApproxEquals(m1ref, 1.0e-9) {
+				t.Errorf("Concurrent elimination %d error c=%s", k, c.String())
+			}
+		}(k)
+	}
+	wg.Wait()
+	for k, e := range errs {
+		if e != nil {
+			t.Errorf("Concurrent elimination %d failed, err: %s", k, e)
+		}
+	}
+}
@@ -0,0 +1,583 @@
+// matrixio.go
+// Reading and writing Matrix and Vector data in the file formats
+// that we use to exchange data with Python and MATLAB.
+//
+// Text:          one row per line, values separated by commas (CSV)
+//                or by white space. Blank lines and lines starting
+//                with # are skipped when reading.
+// Matrix Market: the .mtx exchange format of NIST, array (dense) and
+//                coordinate (sparse) layouts, real or integer values,
+//                general, symmetric or skew-symmetric.
+// NumPy:         the .npy format, version 1.0 for writing and
+//                versions 1.0 to 3.0 for reading, with float64 or
+//                float32 data in either byte order and either of
+//                C or Fortran element order.
+//
+// PJ 2026-10-18
+
+package array
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Longest line accepted by the text readers, well beyond the 64 kB
+// default of bufio.Scanner, so that wide rows can be read.
+const maxLineLength = 1 << 30
+
+func newLineScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineLength)
+	return scanner
+}
+
+// Largest header accepted in an .npy file. Headers written by numpy
+// are a few hundred bytes, and numpy itself refuses long headers.
+const npyMaxHeaderLength = 64 * 1024
+
+// Largest number of elements, or of rows for a sparse matrix, that the
+// readers will allocate for a size that is not backed by values in the
+// file, as when a coordinate file is expanded to a dense Matrix or a
+// row-pointer array is allocated for a sparse one. At 8 bytes each,
+// this is 1 GiB. Use the COO form for larger, sparse matrices.
+const maxImpliedElements = 1 << 27
+
+// Product of the dimensions, checked so that that many elements
+// of size bytes each can be addressed without overflow.
+func elementCount(dims []int, size int) (int, error) {
+	count := 1
+	for _, d := range dims {
+		if d < 0 {
+			return 0, errors.New(fmt.Sprintf("Invalid dimensions %v", dims))
+		}
+		if d > 0 && count > math.MaxInt/size/d {
+			return 0, errors.New(fmt.Sprintf("Dimensions %v are too large", dims))
+		}
+		count *= d
+	}
+	return count, nil
+}
+
+//-----------------------------------------------------------------------------
+// Text
+
+// Write one row per line, with values separated by sep,
+// typically "," for CSV or " " for white-space-separated text.
+func WriteMatrixText(w io.Writer, a *Matrix, sep string) error {
+	bw := bufio.NewWriter(w)
+	for _, row := range a.Data {
+		for j, x := range row {
+			if j > 0 {
+				bw.WriteString(sep)
+			}
+			bw.WriteString(strconv.FormatFloat(x, 'g', -1, 64))
+		}
+		bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
+
+// Write one value per line.
+func WriteVectorText(w io.Writer, a *Vector) error {
+	bw := bufio.NewWriter(w)
+	for _, x := range a.Data {
+		bw.WriteString(strconv.FormatFloat(x, 'g', -1, 64))
+		bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
+
+// Read the numbers on each non-blank line, as separated by commas
+// (if there are any on the line) or by white space otherwise.
+func readTextRows(r io.Reader) ([][]float64, error) {
+	var rows [][]float64
+	scanner := newLineScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var fields []string
+		if strings.Contains(line, ",") {
+			fields = strings.Split(line, ",")
+		} else {
+			fields = strings.Fields(line)
+		}
+		row := make([]float64, len(fields))
+		for j, f := range fields {
+			x, err := strconv.ParseFloat(strings.TrimSpace(f), 64)
+			if err != nil {
+				msg := fmt.Sprintf("Line %d, field %d: invalid number %q", lineNo, j+1, f)
+				return nil, errors.New(msg)
+			}
+			row[j] = x
+		}
+		if len(rows) > 0 && len(row) != len(rows[0]) {
+			msg := fmt.Sprintf("Line %d: ragged rows, expected %d values but found %d",
+				lineNo, len(rows[0]), len(row))
+			return nil, errors.New(msg)
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("No data found")
+	}
+	return rows, nil
+}
+
+func ReadMatrixText(r io.Reader) (*Matrix, error) {
+	rows, err := readTextRows(r)
+	if err != nil {
+		return nil, err
+	}
+	return NewMatrixFromArray(rows)
+}
+
+// Accepts either a single column or a single row of values.
+func ReadVectorText(r io.Reader) (*Vector, error) {
+	rows, err := readTextRows(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 1 {
+		return NewVectorFromArray(rows[0]), nil
+	}
+	if len(rows[0]) != 1 {
+		msg := fmt.Sprintf("Expected a single row or column, found %dx%d values",
+			len(rows), len(rows[0]))
+		return nil, errors.New(msg)
+	}
+	z := NewVector(len(rows))
+	for i := range rows {
+		z.Data[i] = rows[i][0]
+	}
+	return z, nil
+}
+
+//-----------------------------------------------------------------------------
+// Matrix Market
+
+// Write in the dense array layout, which is column-major.
+func WriteMatrixMarket(w io.Writer, a *Matrix) error {
+	nrows, ncols := a.Dims()
+	bw := bufio.NewWriter(w)
+	bw.WriteString("%%MatrixMarket matrix array real general\n")
+	bw.WriteString(fmt.Sprintf("%d %d\n", nrows, ncols))
+	for j := 0; j < ncols; j++ {
+		for i := 0; i < nrows; i++ {
+			bw.WriteString(strconv.FormatFloat(a.Data[i][j], 'g', -1, 64))
+			bw.WriteString("\n")
+		}
+	}
+	return bw.Flush()
+}
+
+// Write a Vector as a single-column matrix.
+func WriteVectorMatrixMarket(w io.Writer, a *Vector) error {
+	m, err := NewMatrix(len(a.Data), 1)
+	if err != nil {
+		return err
+	}
+	for i, x := range a.Data {
+		m.Data[i][0] = x
+	}
+	return WriteMatrixMarket(w, m)
+}
+
+// Write a sparse matrix in the coordinate layout, with 1-based indices.
+func WriteMatrixMarketCSR(w io.Writer, a *CSR) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("%%MatrixMarket matrix coordinate real general\n")
+	bw.WriteString(fmt.Sprintf("%d %d %d\n", a.Rows, a.Cols, a.NNZ()))
+	for i := 0; i < a.Rows; i++ {
+		for k := a.RowPtr[i]; k < a.RowPtr[i+1]; k++ {
+			bw.WriteString(fmt.Sprintf("%d %d %s\n", i+1, a.ColIdx[k]+1,
+				strconv.FormatFloat(a.Values[k], 'g', -1, 64)))
+		}
+	}
+	return bw.Flush()
+}
+
+// Read either layout into coordinate form, expanding the symmetric storage.
+func ReadMatrixMarketCOO(r io.Reader) (*COO, error) {
+	scanner := newLineScanner(r)
+	if !scanner.Scan() {
+		return nil, errors.New("Empty Matrix Market file")
+	}
+	header := strings.Fields(strings.ToLower(scanner.Text()))
+	if len(header) != 5 || header[0] != "%%matrixmarket" || header[1] != "matrix" {
+		msg := fmt.Sprintf("Invalid Matrix Market header: %q", scanner.Text())
+		return nil, errors.New(msg)
+	}
+	layout, field, symmetry := header[2], header[3], header[4]
+	if layout != "array" && layout != "coordinate" {
+		return nil, errors.New(fmt.Sprintf("Unsupported Matrix Market layout: %s", layout))
+	}
+	if field != "real" && field != "integer" && field != "double" &&
+		!(field == "pattern" && layout == "coordinate") {
+		return nil, errors.New(fmt.Sprintf("Unsupported Matrix Market field: %s", field))
+	}
+	if symmetry != "general" && symmetry != "symmetric" && symmetry != "skew-symmetric" {
+		return nil, errors.New(fmt.Sprintf("Unsupported Matrix Market symmetry: %s", symmetry))
+	}
+	// The remaining lines, without comments, as lists of fields.
+	var lines [][]string
+	var lineNos []int
+	lineNo := 1
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "%") {
+			continue
+		}
+		lines = append(lines, strings.Fields(line))
+		lineNos = append(lineNos, lineNo)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("Missing Matrix Market size line")
+	}
+	size := lines[0]
+	if (layout == "array" && len(size) != 2) || (layout == "coordinate" && len(size) != 3) {
+		msg := fmt.Sprintf("Line %d: invalid size line for %s layout", lineNos[0], layout)
+		return nil, errors.New(msg)
+	}
+	dims := make([]int, len(size))
+	for k, s := range size {
+		d, err := strconv.Atoi(s)
+		if err != nil || d < 0 {
+			msg := fmt.Sprintf("Line %d: invalid size %q", lineNos[0], s)
+			return nil, errors.New(msg)
+		}
+		dims[k] = d
+	}
+	nrows, ncols := dims[0], dims[1]
+	if layout == "coordinate" && nrows > maxImpliedElements {
+		msg := fmt.Sprintf("Too many rows for a sparse matrix, nrows=%d limit=%d", nrows, maxImpliedElements)
+		return nil, errors.New(msg)
+	}
+	if symmetry != "general" && nrows != ncols {
+		return nil, errors.New(fmt.Sprintf("Symmetric matrix must be square, found %dx%d", nrows, ncols))
+	}
+	coo, err := NewCOO(nrows, ncols)
+	if err != nil {
+		return nil, err
+	}
+	add := func(i, j int, v float64) {
+		coo.Add(i, j, v)
+		if i != j && symmetry == "symmetric" {
+			coo.Add(j, i, v)
+		}
+		if i != j && symmetry == "skew-symmetric" {
+			coo.Add(j, i, -v)
+		}
+	}
+	entries := lines[1:]
+	if layout == "array" {
+		// Column-major, and only the lower triangle if symmetric.
+		// Check the number of values before going through them,
+		// since the size line may claim far more than the file holds.
+		nvalues, err := elementCount(dims, 8)
+		if err != nil {
+			return nil, err
+		}
+		if symmetry == "symmetric" {
+			nvalues = nrows * (nrows + 1) / 2
+		} else if symmetry == "skew-symmetric" {
+			nvalues = nrows * (nrows - 1) / 2
+		}
+		if len(entries) != nvalues {
+			msg := fmt.Sprintf("Expected %d values for %dx%d %s array, found %d",
+				nvalues, nrows, ncols, symmetry, len(entries))
+			return nil, errors.New(msg)
+		}
+		k := 0
+		for j := 0; j < ncols; j++ {
+			i0 := 0
+			if symmetry == "symmetric" {
+				i0 = j
+			} else if symmetry == "skew-symmetric" {
+				i0 = j + 1
+			}
+			for i := i0; i < nrows; i++ {
+				e := entries[k]
+				if len(e) != 1 {
+					return nil, errors.New(fmt.Sprintf("Line %d: expected one value", lineNos[k+1]))
+				}
+				v, err := strconv.ParseFloat(e[0], 64)
+				if err != nil {
+					return nil, errors.New(fmt.Sprintf("Line %d: invalid number %q", lineNos[k+1], e[0]))
+				}
+				add(i, j, v)
+				k++
+			}
+		}
+		return coo, nil
+	}
+	nnz := dims[2]
+	if len(entries) != nnz {
+		msg := fmt.Sprintf("Expected %d entries, found %d", nnz, len(entries))
+		return nil, errors.New(msg)
+	}
+	nfields := 3
+	if field == "pattern" {
+		nfields = 2
+	}
+	for k, e := range entries {
+		ln := lineNos[k+1]
+		if len(e) != nfields {
+			msg := fmt.Sprintf("Line %d: expected %d fields, found %d", ln, nfields, len(e))
+			return nil, errors.New(msg)
+		}
+		i, err1 := strconv.Atoi(e[0])
+		j, err2 := strconv.Atoi(e[1])
+		if err1 != nil || err2 != nil || i < 1 || i > nrows || j < 1 || j > ncols {
+			msg := fmt.Sprintf("Line %d: invalid index (%s, %s)", ln, e[0], e[1])
+			return nil, errors.New(msg)
+		}
+		v := 1.0
+		if field != "pattern" {
+			v, err = strconv.ParseFloat(e[2], 64)
+			if err != nil {
+				return nil, errors.New(fmt.Sprintf("Line %d: invalid number %q", ln, e[2]))
+			}
+		}
+		add(i-1, j-1, v)
+	}
+	return coo, nil
+}
+
+func ReadMatrixMarket(r io.Reader) (*Matrix, error) {
+	coo, err := ReadMatrixMarketCOO(r)
+	if err != nil {
+		return nil, err
+	}
+	// A sparse file may declare a size that is too big to hold densely.
+	count, err := elementCount([]int{coo.Rows, coo.Cols}, 8)
+	if err != nil {
+		return nil, err
+	}
+	if count > maxImpliedElements && count > len(coo.V) {
+		msg := fmt.Sprintf("Too large to expand to a dense Matrix, %dx%d with %d entries",
+			coo.Rows, coo.Cols, len(coo.V))
+		return nil, errors.New(msg)
+	}
+	return coo.CSR().Matrix()
+}
+
+// Accepts either a single-column or a single-row matrix.
+func ReadVectorMatrixMarket(r io.Reader) (*Vector, error) {
+	a, err := ReadMatrixMarket(r)
+	if err != nil {
+		return nil, err
+	}
+	nrows, ncols := a.Dims()
+	if nrows != 1 && ncols != 1 {
+		msg := fmt.Sprintf("Expected a single row or column, found %dx%d matrix", nrows, ncols)
+		return nil, errors.New(msg)
+	}
+	z := NewVector(nrows * ncols)
+	for i := 0; i < nrows; i++ {
+		for j := 0; j < ncols; j++ {
+			z.Data[i*ncols+j] = a.Data[i][j]
+		}
+	}
+	return z, nil
+}
+
+//-----------------------------------------------------------------------------
+// NumPy .npy
+
+var npyMagic = []byte("\x93NUMPY")
+
+func writeNPY(w io.Writer, shape []int, data []float64) error {
+	dims := make([]string, len(shape))
+	for k, d := range shape {
+		dims[k] = strconv.Itoa(d)
+	}
+	shapeStr := strings.Join(dims, ", ")
+	if len(shape) == 1 {
+		shapeStr += ","
+	}
+	header := fmt.Sprintf("{'descr': '<f8', 'fortran_order': False, 'shape': (%s), }", shapeStr)
+	// Pad with spaces so that the data starts on a 64-byte boundary.
+	total := len(npyMagic) + 2 + 2 + len(header) + 1
+	header += strings.Repeat(" ", (64-total%64)%64) + "\n"
+	var b bytes.Buffer
+	b.Write(npyMagic)
+	b.Write([]byte{1, 0})
+	binary.Write(&b, binary.LittleEndian, uint16(len(header)))
+	b.WriteString(header)
+	buf := make([]byte, 8*len(data))
+	for k, x := range data {
+		binary.LittleEndian.PutUint64(buf[8*k:], math.Float64bits(x))
+	}
+	b.Write(buf)
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+func WriteMatrixNPY(w io.Writer, a *Matrix) error {
+	nrows, ncols := a.Dims()
+	data := make([]float64, 0, nrows*ncols)
+	for _, row := range a.Data {
+		data = append(data, row...)
+	}
+	return writeNPY(w, []int{nrows, ncols}, data)
+}
+
+func WriteVectorNPY(w io.Writer, a *Vector) error {
+	return writeNPY(w, []int{len(a.Data)}, a.Data)
+}
+
+var (
+	npyDescrRE   = regexp.MustCompile(`'descr'\s*:\s*'([^']*)'`)
+	npyFortranRE = regexp.MustCompile(`'fortran_order'\s*:\s*(True|False)`)
+	npyShapeRE   = regexp.MustCompile(`'shape'\s*:\s*\(([^)]*)\)`)
+)
+
+// Returns the shape and the data, in C (row-major) order.
+func readNPY(r io.Reader) ([]int, []float64, error) {
+	prefix := make([]byte, len(npyMagic)+2)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return nil, nil, errors.New("Too short for an .npy file")
+	}
+	if !bytes.Equal(prefix[:len(npyMagic)], npyMagic) {
+		return nil, nil, errors.New("Invalid .npy magic string")
+	}
+	var hlen int
+	switch major := prefix[len(npyMagic)]; major {
+	case 1:
+		var n uint16
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return nil, nil, err
+		}
+		hlen = int(n)
+	case 2, 3:
+		var n uint32
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return nil, nil, err
+		}
+		hlen = int(n)
+	default:
+		return nil, nil, errors.New(fmt.Sprintf("Unsupported .npy version %d", major))
+	}
+	if hlen > npyMaxHeaderLength {
+		msg := fmt.Sprintf("The .npy header is too long, length=%d limit=%d", hlen, npyMaxHeaderLength)
+		return nil, nil, errors.New(msg)
+	}
+	hbytes, err := io.ReadAll(io.LimitReader(r, int64(hlen)))
+	if err != nil || len(hbytes) != hlen {
+		return nil, nil, errors.New("Truncated .npy header")
+	}
+	header := string(hbytes)
+	descr := npyDescrRE.FindStringSubmatch(header)
+	fortran := npyFortranRE.FindStringSubmatch(header)
+	shapeM := npyShapeRE.FindStringSubmatch(header)
+	if descr == nil || fortran == nil || shapeM == nil {
+		return nil, nil, errors.New(fmt.Sprintf("Invalid .npy header: %q", header))
+	}
+	var shape []int
+	for _, s := range strings.Split(shapeM[1], ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		d, err := strconv.Atoi(s)
+		if err != nil || d < 0 {
+			return nil, nil, errors.New(fmt.Sprintf("Invalid .npy shape: (%s)", shapeM[1]))
+		}
+		shape = append(shape, d)
+	}
+	var order binary.ByteOrder
+	var size int
+	switch descr[1] {
+	case "<f8", "|f8":
+		order, size = binary.LittleEndian, 8
+	case ">f8":
+		order, size = binary.BigEndian, 8
+	case "<f4", "|f4":
+		order, size = binary.LittleEndian, 4
+	case ">f4":
+		order, size = binary.BigEndian, 4
+	default:
+		return nil, nil, errors.New(fmt.Sprintf("Unsupported .npy dtype: %s", descr[1]))
+	}
+	count, err := elementCount(shape, size)
+	if err != nil {
+		return nil, nil, errors.New(fmt.Sprintf("Invalid .npy shape: %s", err))
+	}
+	// The buffer grows with the data actually present, rather than
+	// being allocated up front from the untrusted header.
+	buf, err := io.ReadAll(io.LimitReader(r, int64(size*count)))
+	if err != nil || len(buf) != size*count {
+		msg := fmt.Sprintf("Truncated .npy data, expected %d values", count)
+		return nil, nil, errors.New(msg)
+	}
+	data := make([]float64, count)
+	for k := 0; k < count; k++ {
+		if size == 8 {
+			data[k] = math.Float64frombits(order.Uint64(buf[8*k:]))
+		} else {
+			data[k] = float64(math.Float32frombits(order.Uint32(buf[4*k:])))
+		}
+	}
+	if fortran[1] == "True" && len(shape) == 2 {
+		nrows, ncols := shape[0], shape[1]
+		c := make([]float64, count)
+		for i := 0; i < nrows; i++ {
+			for j := 0; j < ncols; j++ {
+				c[i*ncols+j] = data[j*nrows+i]
+			}
+		}
+		data = c
+	}
+	return shape, data, nil
+}
+
+func ReadMatrixNPY(r io.Reader) (*Matrix, error) {
+	shape, data, err := readNPY(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(shape) != 2 {
+		msg := fmt.Sprintf("Expected a 2-dimensional array, found shape %v", shape)
+		return nil, errors.New(msg)
+	}
+	z, err := NewMatrix(shape[0], shape[1])
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < shape[0]; i++ {
+		copy(z.Data[i], data[i*shape[1]:(i+1)*shape[1]])
+	}
+	return z, nil
+}
+
+func ReadVectorNPY(r io.Reader) (*Vector, error) {
+	shape, data, err := readNPY(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(shape) != 1 {
+		msg := fmt.Sprintf("Expected a 1-dimensional array, found shape %v", shape)
+		return nil, errors.New(msg)
+	}
+	return &Vector{Data: data}, nil
+}
@@ -0,0 +1,237 @@
+// matrixio_test.go
+// Try out the text, Matrix Market and NumPy readers and writers.
+// PJ 2026-10-18
+//
+
+package array
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestMatrixText(t *testing.T) {
+	m1, _ := NewMatrixFromArray([][]float64{{1.0, -2.5, 3.0}, {0.1, 5.0e-20, 6.0}})
+	for _, sep := range []string{",", " ", "\t"} {
+		var b bytes.Buffer
+		err := WriteMatrixText(&b, m1, sep)
+		if err != nil {
+			t.Fatalf("WriteMatrixText failed, err: %s", err)
+		}
+		m2, err := ReadMatrixText(&b)
+		if err != nil || !m2.ApproxEquals(m1, 0.0) {
+			t.Errorf("Matrix text round trip error with sep %q err= %v", sep, err)
+		}
+	}
+	text := "# comment\n1, 2\n\n3 , 4\n"
+	m3, err := ReadMatrixText(strings.NewReader(text))
+	m3ref, _ := NewMatrixFromArray([][]float64{{1.0, 2.0}, {3.0, 4.0}})
+	if err != nil || !m3.ApproxEquals(m3ref, 0.0) {
+		t.Errorf("ReadMatrixText error err= %v", err)
+	}
+	_, err = ReadMatrixText(strings.NewReader("1 2\n3\n"))
+	if err == nil || !strings.Contains(err.Error(), "Line 2") {
+		t.Errorf("Did not detect ragged rows, err= %v", err)
+	}
+	_, err = ReadMatrixText(strings.NewReader("1,x\n"))
+	if err == nil {
+		t.Errorf("Did not detect invalid number.")
+	}
+	_, err = ReadMatrixText(strings.NewReader("# nothing\n"))
+	if err == nil {
+		t.Errorf("Did not detect missing data.")
+	}
+
+	v1 := NewVectorFromArray([]float64{1.0, 2.0, 3.0})
+	var b bytes.Buffer
+	WriteVectorText(&b, v1)
+	v2, err := ReadVectorText(&b)
+	if err != nil || !v2.ApproxEquals(v1, 0.0) {
+		t.Errorf("Vector text round trip error err= %v", err)
+	}
+	v3, err := ReadVectorText(strings.NewReader("1 2 3\n"))
+	if err != nil || !v3.ApproxEquals(v1, 0.0) {
+		t.Errorf("Vector text row error err= %v", err)
+	}
+	_, err = ReadVectorText(strings.NewReader("1 2\n3 4\n"))
+	if err == nil {
+		t.Errorf("Did not detect matrix given as a vector.")
+	}
+}
+
+func TestMatrixMarket(t *testing.T) {
+	m1, _ := NewMatrixFromArray([][]float64{{1.0, 2.0, 0.0}, {0.0, 5.0, 6.0}})
+	var b bytes.Buffer
+	WriteMatrixMarket(&b, m1)
+	m2, err := ReadMatrixMarket(&b)
+	if err != nil || !m2.ApproxEquals(m1, 0.0) {
+		t.Errorf("Matrix Market array round trip error err= %v", err)
+	}
+	b.Reset()
+	csr, _ := NewCSRFromMatrix(m1)
+	WriteMatrixMarketCSR(&b, csr)
+	if !strings.Contains(b.String(), "2 3 4\n") {
+		t.Errorf("Matrix Market coordinate size line missing:\n%s", b.String())
+	}
+	m3, err := ReadMatrixMarket(&b)
+	if err != nil || !m3.ApproxEquals(m1, 0.0) {
+		t.Errorf("Matrix Market coordinate round trip error err= %v", err)
+	}
+	// Symmetric storage keeps only the lower triangle.
+	text := `%%MatrixMarket matrix coordinate real symmetric
+% a comment
+3 3 4
+1 1 4.0
+2 1 -1.0
+2 2 4.0
+3 3 2.0
+`
+	m4, err := ReadMatrixMarket(strings.NewReader(text))
+	m4ref, _ := NewMatrixFromArray([][]float64{{4.0, -1.0, 0.0}, {-1.0, 4.0, 0.0}, {0.0, 0.0, 2.0}})
+	if err != nil || !m4.ApproxEquals(m4ref, 0.0) {
+		t.Errorf("Matrix Market symmetric error err= %v", err)
+	}
+	text = "%%MatrixMarket matrix array real skew-symmetric\n2 2\n3.0\n"
+	m5, err := ReadMatrixMarket(strings.NewReader(text))
+	m5ref, _ := NewMatrixFromArray([][]float64{{0.0, -3.0}, {3.0, 0.0}})
+	if err != nil || !m5.ApproxEquals(m5ref, 0.0) {
+		t.Errorf("Matrix Market skew-symmetric array error err= %v", err)
+	}
+	bad := []string{
+		"",
+		"%%MatrixMarket matrix array complex general\n1 1\n1.0\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2 2\n1 1 1.0\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2 1\n3 1 1.0\n",
+		"%%MatrixMarket matrix array real general\n2 2\n1.0\n2.0\n3.0\n",
+		"%%MatrixMarket matrix array real symmetric\n2 3\n1.0\n",
+		// Sizes far beyond the data present, or beyond addressable memory.
+		"%%MatrixMarket matrix array real general\n1000000000 1000000000\n1.0\n",
+		"%%MatrixMarket matrix array real general\n4294967296 4294967296\n1.0\n",
+		"%%MatrixMarket matrix coordinate real general\n4294967296 4294967296 1\n1 1 1.0\n",
+		"%%MatrixMarket matrix coordinate real general\n200000 200000 1\n1 1 1.0\n",
+		"%%MatrixMarket matrix coordinate real general\n100000000000 1 1\n1 1 1.0\n",
+	}
+	for i, s := range bad {
+		_, err = ReadMatrixMarket(strings.NewReader(s))
+		if err == nil {
+			t.Errorf("Did not detect malformed Matrix Market input %d", i)
+		}
+	}
+	// The row pointers of the sparse form are also bounded.
+	_, err = ReadMatrixMarketCOO(strings.NewReader(bad[len(bad)-1]))
+	if err == nil {
+		t.Errorf("Did not detect too many rows for a sparse matrix.")
+	}
+
+	v1 := NewVectorFromArray([]float64{1.0, -2.0, 3.0})
+	b.Reset()
+	WriteVectorMatrixMarket(&b, v1)
+	v2, err := ReadVectorMatrixMarket(&b)
+	if err != nil || !v2.ApproxEquals(v1, 0.0) {
+		t.Errorf("Matrix Market vector round trip error err= %v", err)
+	}
+}
+
+func TestNPY(t *testing.T) {
+	m1, _ := NewMatrixFromArray([][]float64{{1.0, 2.0, 3.0}, {4.0, 5.0, math.Inf(1)}})
+	var b bytes.Buffer
+	WriteMatrixNPY(&b, m1)
+	// The header matches that written by numpy.save for float64 data.
+	header := "{'descr': '<f8', 'fortran_order': False, 'shape': (2, 3), }"
+	if !strings.Contains(b.String(), header) || (b.Len()-6*8)%64 != 0 {
+		t.Errorf("NPY header error got:\n%q", b.String())
+	}
+	m2, err := ReadMatrixNPY(&b)
+	if err != nil || m2.Data[0][2] != 3.0 || !math.IsInf(m2.Data[1][2], 1) {
+		t.Errorf("NPY matrix round trip error err= %v", err)
+	}
+
+	v1 := NewVectorFromArray([]float64{1.0, 2.0})
+	b.Reset()
+	WriteVectorNPY(&b, v1)
+	if !strings.Contains(b.String(), "'shape': (2,)") {
+		t.Errorf("NPY vector shape error got:\n%q", b.String())
+	}
+	v2, err := ReadVectorNPY(bytes.NewReader(b.Bytes()))
+	if err != nil || !v2.ApproxEquals(v1, 0.0) {
+		t.Errorf("NPY vector round trip error err= %v", err)
+	}
+	_, err = ReadMatrixNPY(bytes.NewReader(b.Bytes()))
+	if err == nil {
+		t.Errorf("Did not detect 1-dimensional data read as a matrix.")
+	}
+
+	// Big-endian float32 data in Fortran order, as a 2x2 matrix [[1,2],[3,4]].
+	b.Reset()
+	h := "{'descr': '>f4', 'fortran_order': True, 'shape': (2, 2), }\n"
+	b.WriteString("\x93NUMPY\x01\x00")
+	binary.Write(&b, binary.LittleEndian, uint16(len(h)))
+	b.WriteString(h)
+	for _, x := range []float32{1.0, 3.0, 2.0, 4.0} {
+		binary.Write(&b, binary.BigEndian, x)
+	}
+	m3, err := ReadMatrixNPY(&b)
+	m3ref, _ := NewMatrixFromArray([][]float64{{1.0, 2.0}, {3.0, 4.0}})
+	if err != nil || !m3.ApproxEquals(m3ref, 0.0) {
+		t.Errorf("NPY Fortran-order float32 error err= %v", err)
+	}
+
+	_, err = ReadMatrixNPY(strings.NewReader("NOTNPY\x01\x00"))
+	if err == nil {
+		t.Errorf("Did not detect invalid magic string.")
+	}
+	b.Reset()
+	WriteMatrixNPY(&b, m1)
+	_, err = ReadMatrixNPY(bytes.NewReader(b.Bytes()[:b.Len()-8]))
+	if err == nil {
+		t.Errorf("Did not detect truncated data.")
+	}
+	// Shapes from a malformed header must give an error, not a huge allocation.
+	for _, shape := range []string{"4294967296, 4294967296", "1000000000, 1000000000", "-1, 2", "9223372036854775807,"} {
+		b.Reset()
+		h := "{'descr': '<f8', 'fortran_order': False, 'shape': (" + shape + "), }\n"
+		b.WriteString("\x93NUMPY\x01\x00")
+		binary.Write(&b, binary.LittleEndian, uint16(len(h)))
+		b.WriteString(h)
+		b.Write(make([]byte, 64))
+		_, err = ReadMatrixNPY(bytes.NewReader(b.Bytes()))
+		_, err2 := ReadVectorNPY(bytes.NewReader(b.Bytes()))
+		if err == nil || err2 == nil {
+			t.Errorf("Did not detect malformed shape (%s).", shape)
+		}
+	}
+	// A version 2.0 header length far beyond the data present.
+	b.Reset()
+	b.WriteString("\x93NUMPY\x02\x00")
+	binary.Write(&b, binary.LittleEndian, uint32(0xF0000000))
+	b.WriteString("{'descr': '<f8', 'fortran_order': False, 'shape': (1,), }\n")
+	_, err = ReadVectorNPY(bytes.NewReader(b.Bytes()))
+	if err == nil {
+		t.Errorf("Did not detect an overlong .npy header.")
+	}
+}
+
+func TestWideText(t *testing.T) {
+	// A single row much longer than the 64 kB default line limit of bufio.Scanner.
+	n := 20000
+	m1, _ := NewMatrix(2, n)
+	for j := 0; j < n; j++ {
+		m1.Data[0][j] = 1.0 / float64(j+1)
+		m1.Data[1][j] = float64(j)
+	}
+	var b bytes.Buffer
+	WriteMatrixText(&b, m1, ",")
+	m2, err := ReadMatrixText(&b)
+	if err != nil || !m2.ApproxEquals(m1, 0.0) {
+		t.Errorf("Wide CSV round trip error err= %v", err)
+	}
+	b.Reset()
+	b.WriteString("%%MatrixMarket matrix coordinate real general\n%" + strings.Repeat(" ", 100000) + "\n1 1 1\n1 1 2.0\n")
+	m3, err := ReadMatrixMarket(&b)
+	if err != nil || m3.Data[0][0] != 2.0 {
+		t.Errorf("Matrix Market long comment line error err= %v", err)
+	}
+}
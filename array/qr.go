@@ -0,0 +1,243 @@
+// qr.go
+// Householder QR decomposition of a Matrix with at least as many rows
+// as columns, and the least-squares solution of overdetermined systems.
+//
+// Solving via the QR factors avoids forming the normal equations A^T.A
+// and so does not square the condition number of the problem.
+// The arrangement of the factors follows that of the JAMA library.
+//
+// PJ 2026-10-18
+
+package array
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Spacing of float64 values about 1.0
+const machineEpsilon = 2.220446049250313e-16
+
+type QR struct {
+	// The Householder vectors are stored on and below the diagonal
+	// and the strictly-upper part of R is stored above the diagonal.
+	QR *Matrix
+	// The diagonal of R.
+	RDiag []float64
+	// Options given to NewQR, and the infinity norm of A that they scale.
+	opts  SolverOptions
+	scale float64
+}
+
+// Factor the m-by-n matrix a, with m >= n, such that A = Q.R
+// The matrix a is not altered. The options set the default
+// tolerance on the diagonal of R for Rank and LeastSquares.
+func NewQR(a *Matrix, opts ...SolverOptions) (*QR, error) {
+	m := len(a.Data)
+	if m == 0 {
+		return nil, errors.New("Empty Matrix")
+	}
+	n := len(a.Data[0])
+	if n == 0 {
+		return nil, errors.New("Empty rows in Matrix")
+	}
+	if m < n {
+		msg := fmt.Sprintf("Fewer rows than columns: nrows=%d ncols=%d", m, n)
+		return nil, errors.New(msg)
+	}
+	qr, err := NewMatrixFromArray(a.Data)
+	if err != nil {
+		return nil, err
+	}
+	f := QR{QR: qr, RDiag: make([]float64, n),
+		opts: solverOptions(opts), scale: rowSumScale(a.Data, n)}
+	c := qr.Data
+	for k := 0; k < n; k++ {
+		// Norm of the k-th column, below the diagonal.
+		nrm := 0.0
+		for i := k; i < m; i++ {
+			nrm = math.Hypot(nrm, c[i][k])
+		}
+		if nrm != 0.0 {
+			// Form the k-th Householder vector.
+			if c[k][k] < 0.0 {
+				nrm = -nrm
+			}
+			for i := k; i < m; i++ {
+				c[i][k] /= nrm
+			}
+			c[k][k] += 1.0
+			// Apply the transformation to the remaining columns.
+			for j := k + 1; j < n; j++ {
+				s := 0.0
+				for i := k; i < m; i++ {
+					s += c[i][k] * c[i][j]
+				}
+				s = -s / c[k][k]
+				for i := k; i < m; i++ {
+					c[i][j] += s * c[i][k]
+				}
+			}
+		}
+		f.RDiag[k] = -nrm
+	}
+	return &f, nil
+}
+
+// Number of diagonal elements of R that are larger in magnitude than
+// tol times the largest. A value of tol <= 0 selects a default tolerance
+// based on the matrix size and machine precision.
+// Elements not greater than the pivot threshold of the options,
+// or else of those given to NewQR, are also counted as zero.
+func (f *QR) Rank(tol float64, opts ...SolverOptions) int {
+	m := len(f.QR.Data)
+	n := len(f.RDiag)
+	if tol <= 0.0 {
+		tol = float64(max(m, n)) * machineEpsilon
+	}
+	rmax := 0.0
+	for _, r := range f.RDiag {
+		rmax = math.Max(rmax, math.Abs(r))
+	}
+	o := f.opts
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	threshold := math.Max(tol*rmax, o.pivotThreshold(f.scale))
+	rank := 0
+	for _, r := range f.RDiag {
+		if math.Abs(r) > threshold {
+			rank++
+		}
+	}
+	return rank
+}
+
+// The upper-triangular factor, n-by-n.
+func (f *QR) R() *Matrix {
+	n := len(f.RDiag)
+	r, _ := NewMatrix(n, n)
+	for i := 0; i < n; i++ {
+		r.Data[i][i] = f.RDiag[i]
+		for j := i + 1; j < n; j++ {
+			r.Data[i][j] = f.QR.Data[i][j]
+		}
+	}
+	return r
+}
+
+// The orthogonal factor, in its economical m-by-n form.
+func (f *QR) Q() *Matrix {
+	m := len(f.QR.Data)
+	n := len(f.RDiag)
+	q, _ := NewMatrix(m, n)
+	c := f.QR.Data
+	for k := n - 1; k >= 0; k-- {
+		q.Data[k][k] = 1.0
+		for j := k; j < n; j++ {
+			if c[k][k] == 0.0 {
+				continue
+			}
+			s := 0.0
+			for i := k; i < m; i++ {
+				s += c[i][k] * q.Data[i][j]
+			}
+			s = -s / c[k][k]
+			for i := k; i < m; i++ {
+				q.Data[i][j] += s * c[i][k]
+			}
+		}
+	}
+	return q
+}
+
+// Find x that minimizes the 2-norm of A.x - b and return it,
+// together with the 2-norm of the residual.
+// An error is returned if A is rank deficient, as judged by Rank
+// with its default tolerance and the given options.
+func (f *QR) LeastSquares(x, b *Vector, opts ...SolverOptions) (*Vector, float64, error) {
+	m := len(f.QR.Data)
+	n := len(f.RDiag)
+	if len(x.Data) != n || len(b.Data) != m {
+		msg := fmt.Sprintf("Inconsistent array lengths nrows:%v ncols:%v x:%v b:%v",
+			m, n, len(x.Data), len(b.Data))
+		return x, 0.0, errors.New(msg)
+	}
+	if rank := f.Rank(0.0, opts...); rank < n {
+		msg := fmt.Sprintf("Rank deficient: rank=%d ncols=%d", rank, n)
+		return x, 0.0, errors.New(msg)
+	}
+	y := make([]float64, m)
+	copy(y, b.Data)
+	resid := f.solve(y)
+	copy(x.Data, y[:n])
+	return x, resid, nil
+}
+
+// Least-squares solution for each column of B, returning the 2-norms
+// of the residuals for each column.
+func (f *QR) LeastSquaresMatrix(x, b *Matrix, opts ...SolverOptions) (*Matrix, []float64, error) {
+	m := len(f.QR.Data)
+	n := len(f.RDiag)
+	if len(x.Data) != n || len(b.Data) != m {
+		msg := fmt.Sprintf("Inconsistent matrix rows nrows:%v ncols:%v x:%v b:%v",
+			m, n, len(x.Data), len(b.Data))
+		return x, nil, errors.New(msg)
+	}
+	nrhs := len(b.Data[0])
+	if len(x.Data[0]) != nrhs {
+		msg := fmt.Sprintf("Inconsistent matrix columns x:%v b:%v",
+			len(x.Data[0]), nrhs)
+		return x, nil, errors.New(msg)
+	}
+	if rank := f.Rank(0.0, opts...); rank < n {
+		msg := fmt.Sprintf("Rank deficient: rank=%d ncols=%d", rank, n)
+		return x, nil, errors.New(msg)
+	}
+	resids := make([]float64, nrhs)
+	y := make([]float64, m)
+	for j := 0; j < nrhs; j++ {
+		for i := 0; i < m; i++ {
+			y[i] = b.Data[i][j]
+		}
+		resids[j] = f.solve(y)
+		for i := 0; i < n; i++ {
+			x.Data[i][j] = y[i]
+		}
+	}
+	return x, resids, nil
+}
+
+// Overwrite y with Q^T.y and then solve R.x = y[:n] in place.
+// Returns the norm of the part of Q^T.y that cannot be matched.
+func (f *QR) solve(y []float64) float64 {
+	m := len(f.QR.Data)
+	n := len(f.RDiag)
+	c := f.QR.Data
+	for k := 0; k < n; k++ {
+		if c[k][k] == 0.0 {
+			continue
+		}
+		s := 0.0
+		for i := k; i < m; i++ {
+			s += c[i][k] * y[i]
+		}
+		s = -s / c[k][k]
+		for i := k; i < m; i++ {
+			y[i] += s * c[i][k]
+		}
+	}
+	resid := 0.0
+	for i := n; i < m; i++ {
+		resid = math.Hypot(resid, y[i])
+	}
+	for k := n - 1; k >= 0; k-- {
+		s := y[k]
+		for j := k + 1; j < n; j++ {
+			s -= c[k][j] * y[j]
+		}
+		y[k] = s / f.RDiag[k]
+	}
+	return resid
+}
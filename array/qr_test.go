@@ -0,0 +1,115 @@
+// qr_test.go
+// Try out the QR decomposition and least-squares solver.
+// PJ 2026-10-18
+//
+
+package array
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQR(t *testing.T) {
+	// Fit a straight line, y = c0 + c1*x, to four points.
+	a, _ := NewMatrixFromArray([][]float64{{1.0, 0.0}, {1.0, 1.0}, {1.0, 2.0}, {1.0, 3.0}})
+	f, err := NewQR(a)
+	if err != nil {
+		t.Fatalf("Failed to factor a, err: %s", err)
+	}
+	if f.Rank(0.0) != 2 {
+		t.Errorf("Incorrect rank=%d want=2", f.Rank(0.0))
+	}
+	b := NewVectorFromArray([]float64{1.0, 3.0, 4.0, 4.0})
+	c := NewVector(2)
+	_, resid, err := f.LeastSquares(c, b)
+	cref := NewVectorFromArray([]float64{1.5, 1.0})
+	if err != nil || !c.ApproxEquals(cref, 1.0e-9) {
+		t.Errorf("Least-squares error c=%s want=%s", c.String(), cref.String())
+	}
+	if math.Abs(resid - 1.0) > 1.0e-9 {
+		t.Errorf("Least-squares residual error resid=%g want=1.0", resid)
+	}
+	// Check that Q has orthonormal columns and that Q.R reproduces A.
+	q := f.Q()
+	r := f.R()
+	qtq, _ := NewMatrix(2, 2)
+	eye, _ := NewMatrixFromArray([][]float64{{1.0, 0.0}, {0.0, 1.0}})
+	qr, _ := NewMatrix(4, 2)
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			for k := 0; k < 4; k++ {
+				qtq.Data[i][j] += q.Data[k][i] * q.Data[k][j]
+			}
+		}
+	}
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 2; j++ {
+			for k := 0; k < 2; k++ {
+				qr.Data[i][j] += q.Data[i][k] * r.Data[k][j]
+			}
+		}
+	}
+	if !qtq.ApproxEquals(eye, 1.0e-9) {
+		t.Errorf("Q columns not orthonormal, Q^T.Q=%s", qtq.String())
+	}
+	if !qr.ApproxEquals(a, 1.0e-9) {
+		t.Errorf("Q.R does not reproduce A, Q.R=%s", qr.String())
+	}
+	// Exactly consistent data in the second column.
+	bm, _ := NewMatrixFromArray([][]float64{{1.0, 2.0}, {3.0, 5.0}, {4.0, 8.0}, {4.0, 11.0}})
+	xm, _ := NewMatrix(2, 2)
+	_, resids, err := f.LeastSquaresMatrix(xm, bm)
+	xmref, _ := NewMatrixFromArray([][]float64{{1.5, 2.0}, {1.0, 3.0}})
+	if err != nil || !xm.ApproxEquals(xmref, 1.0e-9) {
+		t.Errorf("Least-squares matrix error x=%s want=%s", xm.String(), xmref.String())
+	}
+	if len(resids) != 2 || math.Abs(resids[0] - 1.0) > 1.0e-9 || math.Abs(resids[1]) > 1.0e-9 {
+		t.Errorf("Least-squares matrix residuals error resids=%v want=[1 0]", resids)
+	}
+	_, _, err = f.LeastSquares(NewVector(3), b)
+	if err == nil {
+		t.Errorf("Least squares should have detected mismatch in lengths.")
+	}
+
+	// Third column is the sum of the first two.
+	d, _ := NewMatrixFromArray([][]float64{{1.0, 0.0, 1.0}, {1.0, 1.0, 2.0}, {1.0, 2.0, 3.0}, {1.0, 3.0, 4.0}})
+	g, err := NewQR(d)
+	if err != nil {
+		t.Fatalf("Failed to factor d, err: %s", err)
+	}
+	if g.Rank(0.0) != 2 {
+		t.Errorf("Incorrect rank=%d want=2", g.Rank(0.0))
+	}
+	_, _, err = g.LeastSquares(NewVector(3), b)
+	if err == nil {
+		t.Errorf("Did not detect rank-deficient matrix d=%s", d.String())
+	}
+	// Nearly rank deficient, so that the outcome depends on the options.
+	d.Data[0][2] += 1.0e-9
+	g, _ = NewQR(d)
+	if g.Rank(0.0) != 3 {
+		t.Errorf("Incorrect rank=%d want=3", g.Rank(0.0))
+	}
+	opts := SolverOptions{RelTol: 1.0e-6}
+	if g.Rank(0.0, opts) != 2 {
+		t.Errorf("Rank did not apply the relative tolerance, rank=%d want=2", g.Rank(0.0, opts))
+	}
+	_, _, err = g.LeastSquares(NewVector(3), b, opts)
+	if err == nil {
+		t.Errorf("Least squares did not apply the relative tolerance.")
+	}
+	g, _ = NewQR(d, opts)
+	if g.Rank(0.0) != 2 {
+		t.Errorf("NewQR options were not used by Rank, rank=%d want=2", g.Rank(0.0))
+	}
+	_, _, err = g.LeastSquares(NewVector(3), b)
+	if err == nil {
+		t.Errorf("NewQR options were not used by LeastSquares.")
+	}
+	w, _ := NewMatrixFromArray([][]float64{{1.0, 2.0, 3.0}, {2.0, 4.0, 5.0}})
+	_, err = NewQR(w)
+	if err == nil {
+		t.Errorf("Did not detect underdetermined matrix w=%s", w.String())
+	}
+}
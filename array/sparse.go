@@ -0,0 +1,177 @@
+// sparse.go
+// Sparse matrices, for systems that are too large to hold as a Matrix.
+//
+// Build up the matrix in coordinate (COO) form, one element at a time
+// and in any order, then convert to compressed sparse row (CSR) form
+// for the matrix-vector products needed by the iterative solvers.
+//
+// PJ 2026-10-18
+
+package array
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Coordinate form: a list of (i, j, value) triplets.
+type COO struct {
+	Rows, Cols int
+	I, J       []int
+	V          []float64
+}
+
+func NewCOO(nrows, ncols int) (*COO, error) {
+	if nrows <= 0 || ncols <= 0 {
+		msg := fmt.Sprintf("Invalid sparse matrix size nrows=%v ncols=%v", nrows, ncols)
+		return nil, errors.New(msg)
+	}
+	return &COO{Rows: nrows, Cols: ncols}, nil
+}
+
+// Add a contribution to element (i,j).
+// Contributions to the same element are summed when converting to CSR,
+// as is convenient when assembling finite-volume or finite-element systems.
+func (a *COO) Add(i, j int, v float64) error {
+	if i < 0 || i >= a.Rows || j < 0 || j >= a.Cols {
+		msg := fmt.Sprintf("Index (%d,%d) outside matrix of size %dx%d", i, j, a.Rows, a.Cols)
+		return errors.New(msg)
+	}
+	a.I = append(a.I, i)
+	a.J = append(a.J, j)
+	a.V = append(a.V, v)
+	return nil
+}
+
+// Convert to CSR form, with sorted column indices in each row
+// and duplicate entries summed.
+func (a *COO) CSR() *CSR {
+	nnz := len(a.V)
+	idx := make([]int, nnz)
+	for k := 0; k < nnz; k++ {
+		idx[k] = k
+	}
+	sort.SliceStable(idx, func(p int, q int) bool {
+		if a.I[idx[p]] != a.I[idx[q]] {
+			return a.I[idx[p]] < a.I[idx[q]]
+		}
+		return a.J[idx[p]] < a.J[idx[q]]
+	})
+	z := CSR{Rows: a.Rows, Cols: a.Cols, RowPtr: make([]int, a.Rows+1)}
+	for p := 0; p < nnz; p++ {
+		k := idx[p]
+		n := len(z.Values)
+		if p > 0 && a.I[k] == a.I[idx[p-1]] && a.J[k] == a.J[idx[p-1]] {
+			z.Values[n-1] += a.V[k]
+			continue
+		}
+		z.ColIdx = append(z.ColIdx, a.J[k])
+		z.Values = append(z.Values, a.V[k])
+		z.RowPtr[a.I[k]+1]++
+	}
+	for i := 0; i < a.Rows; i++ {
+		z.RowPtr[i+1] += z.RowPtr[i]
+	}
+	return &z
+}
+
+// Compressed sparse row form.
+// The entries of row i are at positions RowPtr[i] <= k < RowPtr[i+1]
+// of ColIdx and Values, with the column indices in increasing order.
+type CSR struct {
+	Rows, Cols int
+	RowPtr     []int
+	ColIdx     []int
+	Values     []float64
+}
+
+// Pick out the nonzero elements of a dense matrix.
+func NewCSRFromMatrix(a *Matrix) (*CSR, error) {
+	nrows, ncols := a.Dims()
+	coo, err := NewCOO(nrows, ncols)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < nrows; i++ {
+		for j := 0; j < ncols; j++ {
+			if a.Data[i][j] != 0.0 {
+				coo.Add(i, j, a.Data[i][j])
+			}
+		}
+	}
+	return coo.CSR(), nil
+}
+
+func (a *CSR) NNZ() int {
+	return len(a.Values)
+}
+
+func (a *CSR) At(i, j int) float64 {
+	lo, hi := a.RowPtr[i], a.RowPtr[i+1]
+	k := lo + sort.SearchInts(a.ColIdx[lo:hi], j)
+	if k < hi && a.ColIdx[k] == j {
+		return a.Values[k]
+	}
+	return 0.0
+}
+
+// Infinity norm, the largest absolute row sum.
+func (a *CSR) normInf() float64 {
+	scale := 0.0
+	for i := 0; i < a.Rows; i++ {
+		rowsum := 0.0
+		for k := a.RowPtr[i]; k < a.RowPtr[i+1]; k++ {
+			rowsum += math.Abs(a.Values[k])
+		}
+		scale = math.Max(scale, rowsum)
+	}
+	return scale
+}
+
+func (a *CSR) Diagonal() *Vector {
+	n := min(a.Rows, a.Cols)
+	d := NewVector(n)
+	for i := 0; i < n; i++ {
+		d.Data[i] = a.At(i, i)
+	}
+	return d
+}
+
+// A dense copy, for small matrices and for checking.
+func (a *CSR) Matrix() (*Matrix, error) {
+	z, err := NewMatrix(a.Rows, a.Cols)
+	if err != nil {
+		return z, err
+	}
+	for i := 0; i < a.Rows; i++ {
+		for k := a.RowPtr[i]; k < a.RowPtr[i+1]; k++ {
+			z.Data[i][a.ColIdx[k]] = a.Values[k]
+		}
+	}
+	return z, nil
+}
+
+// Matrix-vector product y = a.x, following the conventions of MulVec.
+func (y *Vector) MulCSR(a *CSR, x *Vector) (*Vector, error) {
+	if len(y.Data) != a.Rows || len(x.Data) != a.Cols {
+		msg := fmt.Sprintf("Inconsistent dimensions y:%v a:%dx%d x:%v",
+			len(y.Data), a.Rows, a.Cols, len(x.Data))
+		return y, errors.New(msg)
+	}
+	if a.Rows == 0 || a.Cols == 0 || len(a.RowPtr) != a.Rows+1 {
+		return y, errors.New("Empty or malformed CSR matrix")
+	}
+	if overlaps(y.Data, x.Data) {
+		return y, errors.New("Result vector must not alias an argument of MulCSR")
+	}
+	for i := 0; i < a.Rows; i++ {
+		s := 0.0
+		for k := a.RowPtr[i]; k < a.RowPtr[i+1]; k++ {
+			s += a.Values[k] * x.Data[a.ColIdx[k]]
+		}
+		y.Data[i] = s
+	}
+	return y, nil
+}
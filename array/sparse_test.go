@@ -0,0 +1,65 @@
+// sparse_test.go
+// Try out the sparse matrix construction and products.
+// PJ 2026-10-18
+//
+
+package array
+
+import (
+	"testing"
+)
+
+func TestSparse(t *testing.T) {
+	coo, err := NewCOO(3, 4)
+	if err != nil {
+		t.Fatalf("Failed to construct COO matrix, err: %s", err)
+	}
+	// Out of order, with a duplicate to be summed.
+	coo.Add(2, 3, 5.0)
+	coo.Add(0, 1, 2.0)
+	coo.Add(1, 0, 3.0)
+	coo.Add(0, 0, 1.0)
+	coo.Add(2, 3, 1.0)
+	coo.Add(1, 2, 4.0)
+	if coo.Add(3, 0, 1.0) == nil {
+		t.Errorf("Did not detect index outside matrix.")
+	}
+	a := coo.CSR()
+	if a.NNZ() != 5 || a.At(2, 3) != 6.0 || a.At(2, 0) != 0.0 {
+		t.Errorf("Incorrect CSR conversion, nnz=%d a=%v", a.NNZ(), a)
+	}
+	m, _ := a.Matrix()
+	mref, _ := NewMatrixFromArray([][]float64{{1.0, 2.0, 0.0, 0.0}, {3.0, 0.0, 4.0, 0.0}, {0.0, 0.0, 0.0, 6.0}})
+	if !m.ApproxEquals(mref, 1.0e-9) {
+		t.Errorf("Incorrect dense copy m=%s want=%s", m.String(), mref.String())
+	}
+	b, _ := NewCSRFromMatrix(mref)
+	if b.NNZ() != 5 || b.At(1, 2) != 4.0 {
+		t.Errorf("Incorrect conversion from dense matrix, nnz=%d", b.NNZ())
+	}
+	x := NewVectorFromArray([]float64{1.0, 1.0, 1.0, 1.0})
+	y := NewVector(3)
+	_, err = y.MulCSR(a, x)
+	yref := NewVectorFromArray([]float64{3.0, 7.0, 6.0})
+	if err != nil || !y.ApproxEquals(yref, 1.0e-9) {
+		t.Errorf("Sparse MulVec error y=%s want=%s", y.String(), yref.String())
+	}
+	_, err = x.MulCSR(a, y)
+	if err == nil {
+		t.Errorf("Sparse MulVec should have detected mismatch in dimensions.")
+	}
+	_, err = (&Vector{}).MulCSR(&CSR{}, &Vector{})
+	if err == nil {
+		t.Errorf("Sparse MulVec should have detected an empty matrix.")
+	}
+	w := NewVector(5)
+	_, err = (&Vector{Data: w.Data[2:5]}).MulCSR(a, &Vector{Data: w.Data[0:4]})
+	if err == nil {
+		t.Errorf("Sparse MulVec should have detected overlapping vectors.")
+	}
+	d := a.Diagonal()
+	dref := NewVectorFromArray([]float64{1.0, 0.0, 0.0})
+	if !d.ApproxEquals(dref, 1.0e-9) {
+		t.Errorf("Incorrect diagonal d=%s want=%s", d.String(), dref.String())
+	}
+}
@@ -0,0 +1,228 @@
+// svd.go
+// Singular value decomposition of a Matrix by one-sided Jacobi rotations,
+// together with the things that we want from it: the Moore-Penrose
+// pseudo-inverse, the numerical rank and the 2-norm condition number.
+//
+// The one-sided Jacobi method orthogonalises the columns of A directly.
+// It is slower than Golub-Kahan bidiagonalisation for large matrices but
+// it is simple and computes the small singular values to high relative
+// accuracy, which is what we want when diagnosing ill-conditioning.
+//
+// PJ 2026-10-18
+
+package array
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+type SVD struct {
+	U *Matrix // m-by-k, orthonormal columns (left singular vectors).
+	S *Vector // k singular values, in descending order.
+	V *Matrix // n-by-k, orthonormal columns (right singular vectors).
+}
+
+// Decompose the m-by-n matrix a, such that A = U.diag(S).V^T
+// with k = min(m,n). The matrix a is not altered.
+// Columns of U that correspond to zero singular values are left as zero.
+func NewSVD(a *Matrix) (*SVD, error) {
+	m := len(a.Data)
+	if m == 0 {
+		return nil, errors.New("Empty Matrix")
+	}
+	n := len(a.Data[0])
+	if n == 0 {
+		return nil, errors.New("Empty rows in Matrix")
+	}
+	if m < n {
+		// Decompose the transpose and swap the roles of U and V.
+		at, _ := NewMatrix(n, m)
+		for i := 0; i < m; i++ {
+			for j := 0; j < n; j++ {
+				at.Data[j][i] = a.Data[i][j]
+			}
+		}
+		f, err := NewSVD(at)
+		if err != nil {
+			return nil, err
+		}
+		f.U, f.V = f.V, f.U
+		return f, nil
+	}
+	u, err := NewMatrixFromArray(a.Data)
+	if err != nil {
+		return nil, err
+	}
+	v, _ := NewMatrix(n, n)
+	for i := 0; i < n; i++ {
+		v.Data[i][i] = 1.0
+	}
+	uc := u.Data
+	vc := v.Data
+	converged := false
+	for sweep := 0; sweep < maxJacobiSweeps; sweep++ {
+		rotated := false
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				alpha, beta, gamma := 0.0, 0.0, 0.0
+				for i := 0; i < m; i++ {
+					alpha += uc[i][p] * uc[i][p]
+					beta += uc[i][q] * uc[i][q]
+					gamma += uc[i][p] * uc[i][q]
+				}
+				if gamma == 0.0 || math.Abs(gamma) <= machineEpsilon*math.Sqrt(alpha*beta) {
+					continue
+				}
+				rotated = true
+				zeta := (beta - alpha) / (2.0 * gamma)
+				tn := 1.0 / (math.Abs(zeta) + math.Sqrt(1.0+zeta*zeta))
+				if zeta < 0.0 {
+					tn = -tn
+				}
+				cs := 1.0 / math.Sqrt(1.0+tn*tn)
+				sn := cs * tn
+				for i := 0; i < m; i++ {
+					uip, uiq := uc[i][p], uc[i][q]
+					uc[i][p] = cs*uip - sn*uiq
+					uc[i][q] = sn*uip + cs*uiq
+				}
+				for i := 0; i < n; i++ {
+					vip, viq := vc[i][p], vc[i][q]
+					vc[i][p] = cs*vip - sn*viq
+					vc[i][q] = sn*vip + cs*viq
+				}
+			}
+		}
+		if !rotated {
+			converged = true
+			break
+		}
+	}
+	if !converged {
+		msg := fmt.Sprintf("Jacobi iteration did not converge in %d sweeps", maxJacobiSweeps)
+		return nil, errors.New(msg)
+	}
+	// The singular values are the norms of the orthogonalised columns.
+	sigma := make([]float64, n)
+	for j := 0; j < n; j++ {
+		s := 0.0
+		for i := 0; i < m; i++ {
+			s = math.Hypot(s, uc[i][j])
+		}
+		sigma[j] = s
+		if s != 0.0 {
+			for i := 0; i < m; i++ {
+				uc[i][j] /= s
+			}
+		}
+	}
+	idx := make([]int, n)
+	for i := 0; i < n; i++ {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i int, j int) bool {
+		return sigma[idx[i]] > sigma[idx[j]]
+	})
+	f := SVD{S: NewVector(n)}
+	f.U, _ = NewMatrix(m, n)
+	f.V, _ = NewMatrix(n, n)
+	for j := 0; j < n; j++ {
+		f.S.Data[j] = sigma[idx[j]]
+		for i := 0; i < m; i++ {
+			f.U.Data[i][j] = uc[i][idx[j]]
+		}
+		for i := 0; i < n; i++ {
+			f.V.Data[i][j] = vc[i][idx[j]]
+		}
+	}
+	return &f, nil
+}
+
+// Singular values not greater than this are treated as zero.
+// A value of tol <= 0 selects a default relative tolerance
+// based on the matrix size and machine precision.
+func (f *SVD) threshold(tol float64) float64 {
+	if tol <= 0.0 {
+		m := len(f.U.Data)
+		n := len(f.V.Data)
+		tol = float64(max(m, n)) * machineEpsilon
+	}
+	return tol * f.S.Data[0]
+}
+
+// Number of singular values larger than tol times the largest.
+func (f *SVD) Rank(tol float64) int {
+	threshold := f.threshold(tol)
+	rank := 0
+	for _, s := range f.S.Data {
+		if s > threshold {
+			rank++
+		}
+	}
+	return rank
+}
+
+// The matrix 2-norm, which is the largest singular value.
+func (f *SVD) Norm2() float64 {
+	return f.S.Data[0]
+}
+
+// The 2-norm condition number, the ratio of the largest to smallest
+// singular values. A singular matrix has an infinite condition number.
+func (f *SVD) Cond() float64 {
+	smin := f.S.Data[len(f.S.Data)-1]
+	if smin == 0.0 {
+		return math.Inf(1)
+	}
+	return f.S.Data[0] / smin
+}
+
+// The n-by-m Moore-Penrose pseudo-inverse, V.diag(1/S).U^T, in which
+// singular values not greater than tol times the largest are discarded.
+func (f *SVD) PseudoInverse(tol float64) *Matrix {
+	m := len(f.U.Data)
+	n := len(f.V.Data)
+	threshold := f.threshold(tol)
+	z, _ := NewMatrix(n, m)
+	for k, s := range f.S.Data {
+		if s <= threshold {
+			continue
+		}
+		for i := 0; i < n; i++ {
+			vik := f.V.Data[i][k] / s
+			for j := 0; j < m; j++ {
+				z.Data[i][j] += vik * f.U.Data[j][k]
+			}
+		}
+	}
+	return z
+}
+
+// Convenience functions for when we do not want to keep the decomposition.
+
+func (a *Matrix) Rank(tol float64) (int, error) {
+	f, err := NewSVD(a)
+	if err != nil {
+		return 0, err
+	}
+	return f.Rank(tol), nil
+}
+
+func (a *Matrix) Cond2() (float64, error) {
+	f, err := NewSVD(a)
+	if err != nil {
+		return 0.0, err
+	}
+	return f.Cond(), nil
+}
+
+func (a *Matrix) PseudoInverse(tol float64) (*Matrix, error) {
+	f, err := NewSVD(a)
+	if err != nil {
+		return nil, err
+	}
+	return f.PseudoInverse(tol), nil
+}
@@ -0,0 +1,72 @@
+// svd_test.go
+// Try out the singular value decomposition and the things derived from it.
+// PJ 2026-10-18
+//
+
+package array
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSVD(t *testing.T) {
+	// A classic example with singular values 5 and 3.
+	a, _ := NewMatrixFromArray([][]float64{{3.0, 2.0, 2.0}, {2.0, 3.0, -2.0}})
+	f, err := NewSVD(a)
+	if err != nil {
+		t.Fatalf("Failed to decompose a, err: %s", err)
+	}
+	sref := NewVectorFromArray([]float64{5.0, 3.0})
+	if !f.S.ApproxEquals(sref, 1.0e-9) {
+		t.Errorf("Incorrect singular values=%s want=%s", f.S.String(), sref.String())
+	}
+	// Reconstruct A from the factors.
+	usv, _ := NewMatrix(2, 3)
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 2; k++ {
+				usv.Data[i][j] += f.U.Data[i][k] * f.S.Data[k] * f.V.Data[j][k]
+			}
+		}
+	}
+	if !usv.ApproxEquals(a, 1.0e-9) {
+		t.Errorf("U.S.V^T does not reproduce A, got=%s", usv.String())
+	}
+	if f.Rank(0.0) != 2 {
+		t.Errorf("Incorrect rank=%d want=2", f.Rank(0.0))
+	}
+	if math.Abs(f.Cond() - 5.0/3.0) > 1.0e-9 {
+		t.Errorf("Incorrect condition number=%g want=%g", f.Cond(), 5.0/3.0)
+	}
+	// For full row rank, A.pinv(A) is the identity.
+	p := f.PseudoInverse(0.0)
+	ap, _ := NewMatrix(2, 2)
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			for k := 0; k < 3; k++ {
+				ap.Data[i][j] += a.Data[i][k] * p.Data[k][j]
+			}
+		}
+	}
+	eye, _ := NewMatrixFromArray([][]float64{{1.0, 0.0}, {0.0, 1.0}})
+	if !ap.ApproxEquals(eye, 1.0e-9) {
+		t.Errorf("Incorrect pseudo-inverse, A.pinv(A)=%s", ap.String())
+	}
+
+	// Rank one, so that the pseudo-inverse is A^T/sum(a_ij^2).
+	r, _ := NewMatrixFromArray([][]float64{{1.0, 2.0}, {2.0, 4.0}, {3.0, 6.0}})
+	rank, err := r.Rank(1.0e-10)
+	if err != nil || rank != 1 {
+		t.Errorf("Incorrect rank=%d want=1", rank)
+	}
+	cond, _ := r.Cond2()
+	if cond < 1.0e12 {
+		t.Errorf("Condition number=%g too small for a singular matrix", cond)
+	}
+	rp, _ := r.PseudoInverse(1.0e-10)
+	rpref, _ := NewMatrixFromArray([][]float64{{1.0/70, 2.0/70, 3.0/70}, {2.0/70, 4.0/70, 6.0/70}})
+	if !rp.ApproxEquals(rpref, 1.0e-9) {
+		t.Errorf("Incorrect pseudo-inverse=%s want=%s", rp.String(), rpref.String())
+	}
+}
@@ -210,3 +210,173 @@ func VectorDot(a, b *Vector) (float64, error) {
 	}
 	return s, nil
 }
+
+// More norms, for when the Euclidian norm is not what we want.
+
+// Sum of magnitudes (L1 norm)
+func (a *Vector) Norm1() float64 {
+	return norm1Of(a.Data)
+}
+
+// Largest magnitude (L-infinity norm)
+func (a *Vector) NormInf() float64 {
+	return normInfOf(a.Data)
+}
+
+// General p-norm, for p >= 1, with p = +Inf giving NormInf.
+func (a *Vector) NormP(p float64) (float64, error) {
+	return normPOf(a.Data, p)
+}
+
+// Reductions over the elements.
+// For an empty Vector, Min and Max return +Inf and -Inf,
+// and ArgMin and ArgMax return -1.
+
+func (a *Vector) Min() float64 {
+	s := math.Inf(1)
+	for _, d := range a.Data {
+		s = math.Min(s, d)
+	}
+	return s
+}
+
+func (a *Vector) Max() float64 {
+	s := math.Inf(-1)
+	for _, d := range a.Data {
+		s = math.Max(s, d)
+	}
+	return s
+}
+
+// Index of the first occurrence of the smallest element.
+func (a *Vector) ArgMin() int {
+	k := -1
+	for i, d := range a.Data {
+		if k < 0 || d < a.Data[k] {
+			k = i
+		}
+	}
+	return k
+}
+
+// Index of the first occurrence of the largest element.
+func (a *Vector) ArgMax() int {
+	k := -1
+	for i, d := range a.Data {
+		if k < 0 || d > a.Data[k] {
+			k = i
+		}
+	}
+	return k
+}
+
+// Sample variance, with the n-1 denominator.
+// Returns zero for fewer than two elements.
+func (a *Vector) Variance() float64 {
+	n := len(a.Data)
+	if n < 2 {
+		return 0.0
+	}
+	mean := a.Mean()
+	s := 0.0
+	for _, d := range a.Data {
+		s += (d - mean) * (d - mean)
+	}
+	return s/float64(n-1)
+}
+
+// Sample standard deviation.
+func (a *Vector) StdDev() float64 {
+	return math.Sqrt(a.Variance())
+}
+
+// Element-wise arithmetic, following the conventions of Add.
+
+func (z *Vector) Mul(a, b *Vector) (*Vector, error) {
+	n := len(z.Data)
+	if n != len(a.Data) || n != len(b.Data) {
+		msg := fmt.Sprintf("Inconsistent array lengths z:%v a:%v b:%v",
+			len(z.Data), len(a.Data), len(b.Data))
+		return z, errors.New(msg)
+	}
+	for i := 0; i < n; i++ {
+		z.Data[i] = a.Data[i] * b.Data[i]
+	}
+	return z, nil
+}
+
+func (z *Vector) Div(a, b *Vector) (*Vector, error) {
+	n := len(z.Data)
+	if n != len(a.Data) || n != len(b.Data) {
+		msg := fmt.Sprintf("Inconsistent array lengths z:%v a:%v b:%v",
+			len(z.Data), len(a.Data), len(b.Data))
+		return z, errors.New(msg)
+	}
+	for i := 0; i < n; i++ {
+		z.Data[i] = a.Data[i] / b.Data[i]
+	}
+	return z, nil
+}
+
+// z = alpha*x + y
+func (z *Vector) Axpy(alpha float64, x, y *Vector) (*Vector, error) {
+	n := len(z.Data)
+	if n != len(x.Data) || n != len(y.Data) {
+		msg := fmt.Sprintf("Inconsistent array lengths z:%v x:%v y:%v",
+			len(z.Data), len(x.Data), len(y.Data))
+		return z, errors.New(msg)
+	}
+	for i := 0; i < n; i++ {
+		z.Data[i] = alpha*x.Data[i] + y.Data[i]
+	}
+	return z, nil
+}
+
+// z[i] = f(a[i])
+func (z *Vector) Apply(f func(float64) float64, a *Vector) (*Vector, error) {
+	n := len(z.Data)
+	if n != len(a.Data) {
+		msg := fmt.Sprintf("Inconsistent array lengths z:%v a:%v", len(z.Data), len(a.Data))
+		return z, errors.New(msg)
+	}
+	for i := 0; i < n; i++ {
+		z.Data[i] = f(a.Data[i])
+	}
+	return z, nil
+}
+
+// Cumulative sum, z[i] = a[0] + ... + a[i]
+func (z *Vector) CumSum(a *Vector) (*Vector, error) {
+	n := len(z.Data)
+	if n != len(a.Data) {
+		msg := fmt.Sprintf("Inconsistent array lengths z:%v a:%v", len(z.Data), len(a.Data))
+		return z, errors.New(msg)
+	}
+	s := 0.0
+	for i := 0; i < n; i++ {
+		s += a.Data[i]
+		z.Data[i] = s
+	}
+	return z, nil
+}
+
+// n evenly-spaced values from start to stop, inclusive.
+func Linspace(start, stop float64, n int) *Vector {
+	if n <= 1 {
+		return NewVector(max(n, 0)).SetFromScalar(start)
+	}
+	z := NewVector(n)
+	for i := 0; i < n; i++ {
+		z.Data[i] = start + (stop-start)*float64(i)/float64(n-1)
+	}
+	return z
+}
+
+// n values from 10^start to 10^stop, evenly spaced on a log scale.
+func Logspace(start, stop float64, n int) *Vector {
+	z := Linspace(start, stop, n)
+	for i := 0; i < len(z.Data); i++ {
+		z.Data[i] = math.Pow(10.0, z.Data[i])
+	}
+	return z
+}
@@ -82,3 +82,73 @@ func TestVector(t *testing.T) {
 		t.Errorf("Vector dot product error s= %v want= %v", s, 4.0)
 	}
 }
+
+func TestVectorOps(t *testing.T) {
+	v1 := NewVectorFromArray([]float64{3.0, -4.0, 1.0, -4.0})
+	if v1.Norm1() != 12.0 || v1.NormInf() != 4.0 {
+		t.Errorf("Vector norm error L1=%v Linf=%v want= 12.0 4.0", v1.Norm1(), v1.NormInf())
+	}
+	p2, err := v1.NormP(2.0)
+	if err != nil || math.Abs(p2 - v1.Mag()) > 1.0e-9 {
+		t.Errorf("Vector p-norm error p2=%v want= %v", p2, v1.Mag())
+	}
+	pinf, _ := v1.NormP(math.Inf(1))
+	if pinf != 4.0 {
+		t.Errorf("Vector p-norm error pinf=%v want= 4.0", pinf)
+	}
+	_, err = v1.NormP(0.5)
+	if err == nil {
+		t.Errorf("Vector p-norm should have rejected p < 1.")
+	}
+	if v1.Min() != -4.0 || v1.Max() != 3.0 || v1.ArgMin() != 1 || v1.ArgMax() != 0 {
+		t.Errorf("Vector min/max error min=%v max=%v argmin=%v argmax=%v",
+			v1.Min(), v1.Max(), v1.ArgMin(), v1.ArgMax())
+	}
+	v0 := Vector{}
+	if v0.ArgMin() != -1 || !math.IsInf(v0.Max(), -1) {
+		t.Errorf("Vector min/max error for empty vector")
+	}
+	v2 := NewVectorFromArray([]float64{2.0, 4.0, 4.0, 4.0, 5.0, 5.0, 7.0, 9.0})
+	if math.Abs(v2.Variance() - 32.0/7.0) > 1.0e-9 || math.Abs(v2.StdDev() - math.Sqrt(32.0/7.0)) > 1.0e-9 {
+		t.Errorf("Vector variance error var=%v want= %v", v2.Variance(), 32.0/7.0)
+	}
+	v3 := NewVectorFromArray([]float64{1.0, 2.0, 4.0, 8.0})
+	v4 := NewVector(4)
+	v4.Mul(v1, v3)
+	v4ref := NewVectorFromArray([]float64{3.0, -8.0, 4.0, -32.0})
+	if !v4.ApproxEquals(v4ref, 1.0e-9) {
+		t.Errorf("Vector element-wise Mul error v4= %v want= %v", v4.String(), v4ref.String())
+	}
+	v4.Div(v4, v3)
+	if !v4.ApproxEquals(v1, 1.0e-9) {
+		t.Errorf("Vector element-wise Div error v4= %v want= %v", v4.String(), v1.String())
+	}
+	_, err = v4.Mul(v1, v2)
+	if err == nil {
+		t.Errorf("Vector Mul should have detected mismatch in lengths.")
+	}
+	v4.Axpy(2.0, v3, v1)
+	v4ref = NewVectorFromArray([]float64{5.0, 0.0, 9.0, 12.0})
+	if !v4.ApproxEquals(v4ref, 1.0e-9) {
+		t.Errorf("Vector Axpy error v4= %v want= %v", v4.String(), v4ref.String())
+	}
+	v4.Apply(math.Sqrt, v3)
+	if math.Abs(v4.Data[3] - math.Sqrt(8.0)) > 1.0e-9 {
+		t.Errorf("Vector Apply error v4= %v", v4.String())
+	}
+	v4.CumSum(v3)
+	v4ref = NewVectorFromArray([]float64{1.0, 3.0, 7.0, 15.0})
+	if !v4.ApproxEquals(v4ref, 1.0e-9) {
+		t.Errorf("Vector CumSum error v4= %v want= %v", v4.String(), v4ref.String())
+	}
+	v5 := Linspace(0.0, 1.0, 5)
+	v5ref := NewVectorFromArray([]float64{0.0, 0.25, 0.5, 0.75, 1.0})
+	if !v5.ApproxEquals(v5ref, 1.0e-9) {
+		t.Errorf("Linspace error v5= %v want= %v", v5.String(), v5ref.String())
+	}
+	v6 := Logspace(0.0, 3.0, 4)
+	v6ref := NewVectorFromArray([]float64{1.0, 10.0, 100.0, 1000.0})
+	if !v6.ApproxEquals(v6ref, 1.0e-9) {
+		t.Errorf("Logspace error v6= %v want= %v", v6.String(), v6ref.String())
+	}
+}
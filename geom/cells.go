@@ -0,0 +1,107 @@
+// cells.go
+// Geometric properties of finite-volume cells and their faces,
+// computed from the vertex positions.
+//
+// Vertices are numbered as for the VTK cell types, that is, the base
+// face of the tetrahedron, pyramid and hexahedron is ordered counter-clockwise
+// when viewed from the opposite vertex or face, while the base triangle of
+// the wedge is ordered clockwise when viewed from the top triangle.
+//
+// Faces of the 3-D cells need not be planar. Each quadrilateral face is split
+// into four triangles about the average of its vertices and the volume is
+// accumulated from tetrahedra formed with those triangles and the average
+// of the cell's vertices. A shared face is split in the same way for both of
+// its cells, so the volumes of neighbouring cells fill space exactly.
+//
+// PJ 2026-10-18
+
+package geom
+
+// Properties of a, possibly non-planar, quadrilateral p0 p1 p2 p3.
+// The unit normal follows the right-hand rule for the vertex order and the
+// area is the magnitude of the vector area, which is the projected area seen
+// along that normal and is the area that enters the surface integral of a flux.
+func QuadProperties(p [4]Vector3) (centroid, normal Vector3, area float64) {
+	mid := p[0].Add(p[1]).Add(p[2]).Add(p[3]).Mul(0.25)
+	var vecArea, moment Vector3
+	sumArea := 0.0
+	for k := 0; k < 4; k++ {
+		tri := Triangle{p[k], p[(k+1)%4], mid}
+		va := tri.B.Sub(tri.A).Cross(tri.C.Sub(tri.A)).Mul(0.5)
+		vecArea = vecArea.Add(va)
+		moment = moment.Add(tri.Centroid().Mul(va.Norm()))
+		sumArea += va.Norm()
+	}
+	if sumArea == 0.0 {
+		return mid, Vector3{}, 0.0
+	}
+	return moment.Div(sumArea), vecArea.Unit(), vecArea.Norm()
+}
+
+// Signed volume, positive when p3 is on the side of the triangle p0 p1 p2
+// toward which its right-hand-rule normal points.
+func TetVolume(p0, p1, p2, p3 Vector3) float64 {
+	return p1.Sub(p0).Cross(p2.Sub(p0)).Dot(p3.Sub(p0)) / 6.0
+}
+
+func TetProperties(p [4]Vector3) (centroid Vector3, volume float64) {
+	centroid = p[0].Add(p[1]).Add(p[2]).Add(p[3]).Mul(0.25)
+	return centroid, TetVolume(p[0], p[1], p[2], p[3])
+}
+
+// Faces of the cells, with vertices ordered so that the normals point outward.
+var (
+	pyramidFaces = [][]int{{0, 3, 2, 1}, {0, 1, 4}, {1, 2, 4}, {2, 3, 4}, {3, 0, 4}}
+	wedgeFaces   = [][]int{{0, 1, 2}, {3, 5, 4}, {0, 3, 4, 1}, {1, 4, 5, 2}, {2, 5, 3, 0}}
+	hexFaces     = [][]int{
+		{0, 3, 2, 1}, {4, 5, 6, 7},
+		{0, 1, 5, 4}, {1, 2, 6, 5}, {2, 3, 7, 6}, {3, 0, 4, 7},
+	}
+)
+
+// Volume and centroid of a polyhedron with triangular and quadrilateral faces,
+// from tetrahedra with their apex at the average of the vertices.
+func polyhedronProperties(p []Vector3, faces [][]int) (centroid Vector3, volume float64) {
+	var apex Vector3
+	for _, v := range p {
+		apex = apex.Add(v)
+	}
+	apex = apex.Div(float64(len(p)))
+	var moment Vector3
+	addTet := func(a, b, c Vector3) {
+		// With the face normal outward and the apex inside, this is positive.
+		v := TetVolume(apex, a, b, c)
+		volume += v
+		moment = moment.Add(apex.Add(a).Add(b).Add(c).Mul(0.25 * v))
+	}
+	for _, f := range faces {
+		if len(f) == 3 {
+			addTet(p[f[0]], p[f[1]], p[f[2]])
+			continue
+		}
+		mid := p[f[0]].Add(p[f[1]]).Add(p[f[2]]).Add(p[f[3]]).Mul(0.25)
+		for k := 0; k < 4; k++ {
+			addTet(p[f[k]], p[f[(k+1)%4]], mid)
+		}
+	}
+	if volume == 0.0 {
+		return apex, 0.0
+	}
+	return moment.Div(volume), volume
+}
+
+// Base quadrilateral p0 p1 p2 p3 and apex p4.
+func PyramidProperties(p [5]Vector3) (centroid Vector3, volume float64) {
+	return polyhedronProperties(p[:], pyramidFaces)
+}
+
+// Base triangle p0 p1 p2 and top triangle p3 p4 p5, with p3 above p0, etc.
+func WedgeProperties(p [6]Vector3) (centroid Vector3, volume float64) {
+	return polyhedronProperties(p[:], wedgeFaces)
+}
+
+// Bottom quadrilateral p0 p1 p2 p3 and top quadrilateral p4 p5 p6 p7,
+// with p4 above p0, etc.
+func HexProperties(p [8]Vector3) (centroid Vector3, volume float64) {
+	return polyhedronProperties(p[:], hexFaces)
+}
@@ -0,0 +1,92 @@
+// cells_test.go
+// Check the cell geometry against shapes with known properties.
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+// Unit cube vertices in VTK hexahedron order.
+var unitCube = [8]Vector3{
+	{0.0, 0.0, 0.0}, {1.0, 0.0, 0.0}, {1.0, 1.0, 0.0}, {0.0, 1.0, 0.0},
+	{0.0, 0.0, 1.0}, {1.0, 0.0, 1.0}, {1.0, 1.0, 1.0}, {0.0, 1.0, 1.0},
+}
+
+func TestQuadProperties(t *testing.T) {
+	// Symmetric trapezoid with parallel sides 2 and 1, and height 1.
+	q := [4]Vector3{{0.0, 0.0, 0.0}, {2.0, 0.0, 0.0}, {1.5, 1.0, 0.0}, {0.5, 1.0, 0.0}}
+	c, n, area := QuadProperties(q)
+	if math.Abs(area-1.5) > 1.0e-12 || !n.ApproxEquals(Vector3{0.0, 0.0, 1.0}, 1.0e-12) ||
+		!c.ApproxEquals(Vector3{1.0, 4.0 / 9.0, 0.0}, 1.0e-12) {
+		t.Errorf("Trapezoid properties error c= %v n= %v area= %v", c, n, area)
+	}
+	// Reversing the order flips the normal.
+	_, n, _ = QuadProperties([4]Vector3{q[0], q[3], q[2], q[1]})
+	if !n.ApproxEquals(Vector3{0.0, 0.0, -1.0}, 1.0e-12) {
+		t.Errorf("Reversed quad normal error n= %v", n)
+	}
+	// A warped quad has a vector area equal to that of its projection.
+	w := [4]Vector3{{0.0, 0.0, 0.0}, {1.0, 0.0, 0.2}, {1.0, 1.0, 0.0}, {0.0, 1.0, 0.2}}
+	c, n, area = QuadProperties(w)
+	if math.Abs(area-1.0) > 1.0e-12 || !n.ApproxEquals(Vector3{0.0, 0.0, 1.0}, 1.0e-12) ||
+		!c.ApproxEquals(Vector3{0.5, 0.5, 0.1}, 1.0e-12) {
+		t.Errorf("Warped quad properties error c= %v n= %v area= %v", c, n, area)
+	}
+}
+
+func TestCellVolumes(t *testing.T) {
+	c, v := HexProperties(unitCube)
+	if math.Abs(v-1.0) > 1.0e-12 || !c.ApproxEquals(Vector3{0.5, 0.5, 0.5}, 1.0e-12) {
+		t.Errorf("Unit cube error c= %v v= %v", c, v)
+	}
+	// An affine map of the cube scales the volume by the determinant
+	// and maps the centroid to the centroid.
+	m := Matrix3{{2.0, 0.5, 0.0}, {0.0, 1.5, 0.3}, {0.1, 0.0, 3.0}}
+	shift := Vector3{1.0, -2.0, 0.5}
+	var h [8]Vector3
+	for i, p := range unitCube {
+		h[i] = m.MulVec(p).Add(shift)
+	}
+	c, v = HexProperties(h)
+	if math.Abs(v-m.Det()) > 1.0e-12 || !c.ApproxEquals(m.MulVec(Vector3{0.5, 0.5, 0.5}).Add(shift), 1.0e-12) {
+		t.Errorf("Sheared hex error c= %v v= %v want v= %v", c, v, m.Det())
+	}
+
+	c, v = TetProperties([4]Vector3{{0.0, 0.0, 0.0}, {1.0, 0.0, 0.0}, {0.0, 1.0, 0.0}, {0.0, 0.0, 1.0}})
+	if math.Abs(v-1.0/6.0) > 1.0e-12 || !c.ApproxEquals(Vector3{0.25, 0.25, 0.25}, 1.0e-12) {
+		t.Errorf("Unit tet error c= %v v= %v", c, v)
+	}
+
+	// Half of the unit cube, cut along the diagonal x + y = 1.
+	wedge := [6]Vector3{
+		{0.0, 0.0, 0.0}, {0.0, 1.0, 0.0}, {1.0, 0.0, 0.0},
+		{0.0, 0.0, 1.0}, {0.0, 1.0, 1.0}, {1.0, 0.0, 1.0},
+	}
+	c, v = WedgeProperties(wedge)
+	if math.Abs(v-0.5) > 1.0e-12 || !c.ApproxEquals(Vector3{1.0 / 3.0, 1.0 / 3.0, 0.5}, 1.0e-12) {
+		t.Errorf("Wedge error c= %v v= %v", c, v)
+	}
+
+	// Square base of side 2 and height 3, with the apex off-centre.
+	pyr := [5]Vector3{
+		{0.0, 0.0, 0.0}, {2.0, 0.0, 0.0}, {2.0, 2.0, 0.0}, {0.0, 2.0, 0.0}, {0.5, 0.5, 3.0},
+	}
+	c, v = PyramidProperties(pyr)
+	// The centroid lies a quarter of the way from the base centroid to the apex.
+	cref := Vector3{1.0, 1.0, 0.0}.Lerp(pyr[4], 0.25)
+	if math.Abs(v-4.0) > 1.0e-12 || !c.ApproxEquals(cref, 1.0e-12) {
+		t.Errorf("Pyramid error c= %v v= %v want c= %v", c, v, cref)
+	}
+
+	// A hex with a warped top face z = 1 + 0.4.x.y, for which the volume
+	// under the bilinear surface is 1.1, as is that under the four triangles.
+	h = unitCube
+	h[6].Z = 1.4
+	_, v = HexProperties(h)
+	if math.Abs(v-1.1) > 1.0e-12 {
+		t.Errorf("Warped hex error v= %v want= 1.1", v)
+	}
+}
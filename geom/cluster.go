@@ -0,0 +1,131 @@
+// cluster.go
+// Clustering functions for distributing grid nodes along a parameter.
+//
+// Each maps [0, 1] monotonically onto [0, 1], with y(0) = 0 and y(1) = 1,
+// and the nodes are placed at y(i/(n-1)). Where the slope of y is small
+// the nodes are close together.
+//
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+type ClusterFunction interface {
+	Eval(x float64) float64
+}
+
+// Evenly-spaced nodes.
+type LinearCluster struct{}
+
+func (c LinearCluster) Eval(x float64) float64 {
+	return x
+}
+
+//-----------------------------------------------------------------------------
+// Roberts
+
+// The stretching function of G.O. Roberts (1971), as presented in
+// J.D. Anderson (1995) Computational Fluid Dynamics, section 5.6.
+// Beta > 1 and values closer to 1 give stronger clustering.
+type RobertsCluster struct {
+	End0, End1 bool
+	Beta       float64
+}
+
+func NewRobertsCluster(end0, end1 bool, beta float64) (*RobertsCluster, error) {
+	if !(beta > 1.0) {
+		return nil, errors.New(fmt.Sprintf("Roberts clustering needs beta > 1, found %g", beta))
+	}
+	return &RobertsCluster{End0: end0, End1: end1, Beta: beta}, nil
+}
+
+// With alpha = 0, the nodes cluster toward x = 1 and
+// with alpha = 1/2, they cluster toward both ends.
+func roberts(x, alpha, beta float64) float64 {
+	lambda := (beta + 1.0) / (beta - 1.0)
+	tmp := math.Pow(lambda, (x-alpha)/(1.0-alpha))
+	return ((beta+2.0*alpha)*tmp - beta + 2.0*alpha) / ((2.0*alpha + 1.0) * (1.0 + tmp))
+}
+
+func (c *RobertsCluster) Eval(x float64) float64 {
+	switch {
+	case c.End0 && c.End1:
+		return roberts(x, 0.5, c.Beta)
+	case c.End1:
+		return roberts(x, 0.0, c.Beta)
+	case c.End0:
+		return 1.0 - roberts(1.0-x, 0.0, c.Beta)
+	}
+	return x
+}
+
+//-----------------------------------------------------------------------------
+// Geometric
+
+// Cell sizes in geometric progression, each Ratio times the previous
+// one, for N cells starting from x = 0, or from x = 1 when Reversed.
+type GeometricCluster struct {
+	Ratio    float64
+	N        int
+	Reversed bool
+}
+
+func NewGeometricCluster(ratio float64, n int, reversed bool) (*GeometricCluster, error) {
+	if !(ratio > 0.0) || n < 1 {
+		msg := fmt.Sprintf("Geometric clustering needs ratio > 0 and n >= 1, found %g and %d", ratio, n)
+		return nil, errors.New(msg)
+	}
+	return &GeometricCluster{Ratio: ratio, N: n, Reversed: reversed}, nil
+}
+
+func (c *GeometricCluster) Eval(x float64) float64 {
+	if c.Reversed {
+		return 1.0 - c.forward(1.0-x)
+	}
+	return c.forward(x)
+}
+
+func (c *GeometricCluster) forward(x float64) float64 {
+	if math.Abs(c.Ratio-1.0) < 1.0e-12 {
+		return x
+	}
+	n := float64(c.N)
+	// Written with Expm1 to keep accuracy for ratios near 1.
+	lr := math.Log(c.Ratio)
+	return math.Expm1(x*n*lr) / math.Expm1(n*lr)
+}
+
+//-----------------------------------------------------------------------------
+// Hyperbolic tangent
+
+// Clustering by the hyperbolic tangent toward one or both ends,
+// with larger Beta giving stronger clustering.
+type TanhCluster struct {
+	End0, End1 bool
+	Beta       float64
+}
+
+func NewTanhCluster(end0, end1 bool, beta float64) (*TanhCluster, error) {
+	if !(beta > 0.0) {
+		return nil, errors.New(fmt.Sprintf("Tanh clustering needs beta > 0, found %g", beta))
+	}
+	return &TanhCluster{End0: end0, End1: end1, Beta: beta}, nil
+}
+
+func (c *TanhCluster) Eval(x float64) float64 {
+	b := c.Beta
+	switch {
+	case c.End0 && c.End1:
+		return 0.5 * (1.0 + math.Tanh(b*(x-0.5))/math.Tanh(0.5*b))
+	case c.End0:
+		return 1.0 + math.Tanh(b*(x-1.0))/math.Tanh(b)
+	case c.End1:
+		return math.Tanh(b*x) / math.Tanh(b)
+	}
+	return x
+}
@@ -0,0 +1,78 @@
+// cluster_test.go
+// Check that the clustering functions are monotone and cluster the right way.
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+// Sizes of the first and last of n cells.
+func endCells(c ClusterFunction, n int) (first, last float64) {
+	x := clusteredParams(n+1, c)
+	return x[1] - x[0], x[n] - x[n-1]
+}
+
+func TestClusterFunctions(t *testing.T) {
+	rob0, _ := NewRobertsCluster(true, false, 1.05)
+	rob1, _ := NewRobertsCluster(false, true, 1.05)
+	rob01, _ := NewRobertsCluster(true, true, 1.05)
+	geo, _ := NewGeometricCluster(1.2, 10, false)
+	geoR, _ := NewGeometricCluster(1.2, 10, true)
+	tanh0, _ := NewTanhCluster(true, false, 3.0)
+	tanh1, _ := NewTanhCluster(false, true, 3.0)
+	tanh01, _ := NewTanhCluster(true, true, 3.0)
+	cases := []struct {
+		name       string
+		c          ClusterFunction
+		end0, end1 bool
+	}{
+		{"Roberts end 0", rob0, true, false},
+		{"Roberts end 1", rob1, false, true},
+		{"Roberts both ends", rob01, true, true},
+		{"geometric", geo, true, false},
+		{"geometric reversed", geoR, false, true},
+		{"tanh end 0", tanh0, true, false},
+		{"tanh end 1", tanh1, false, true},
+		{"tanh both ends", tanh01, true, true},
+	}
+	n := 10
+	for _, cs := range cases {
+		if math.Abs(cs.c.Eval(0.0)) > 1.0e-12 || math.Abs(cs.c.Eval(1.0)-1.0) > 1.0e-12 {
+			t.Errorf("%s clustering end values error: %v %v", cs.name, cs.c.Eval(0.0), cs.c.Eval(1.0))
+		}
+		x := clusteredParams(n+1, cs.c)
+		for i := 1; i <= n; i++ {
+			if x[i] <= x[i-1] {
+				t.Errorf("%s clustering is not monotone: %v", cs.name, x)
+				break
+			}
+		}
+		first, last := endCells(cs.c, n)
+		mid := x[n/2+1] - x[n/2]
+		if (cs.end0 && first >= mid) || (cs.end1 && last >= mid) ||
+			(!cs.end0 && first <= mid) || (!cs.end1 && last <= mid) {
+			t.Errorf("%s clustering in the wrong place: first= %v mid= %v last= %v", cs.name, first, mid, last)
+		}
+	}
+	// Geometric cells grow by the ratio exactly.
+	x := clusteredParams(11, geo)
+	if math.Abs((x[2]-x[1])/(x[1]-x[0])-1.2) > 1.0e-12 || math.Abs((x[10]-x[9])/(x[9]-x[8])-1.2) > 1.0e-12 {
+		t.Errorf("Geometric ratio error x= %v", x)
+	}
+	geo1, _ := NewGeometricCluster(1.0, 10, false)
+	if geo1.Eval(0.3) != 0.3 {
+		t.Errorf("Unit-ratio geometric clustering should be linear.")
+	}
+	if _, err := NewRobertsCluster(true, false, 1.0); err == nil {
+		t.Errorf("Did not detect Roberts beta <= 1.")
+	}
+	if _, err := NewGeometricCluster(1.1, 0, false); err == nil {
+		t.Errorf("Did not detect zero cells for geometric clustering.")
+	}
+	if _, err := NewTanhCluster(true, true, -1.0); err == nil {
+		t.Errorf("Did not detect negative tanh beta.")
+	}
+}
@@ -0,0 +1,102 @@
+// coords.go
+// Cylindrical and spherical coordinates, for positions and for the
+// components of vectors such as velocity at those positions.
+//
+// Cylindrical (r, theta, z): r from the z-axis and azimuth theta from +x.
+// Spherical (r, theta, phi): r from the origin, polar angle theta from +z
+// and azimuth phi from +x, the physics (ISO 80000-2) convention.
+// Azimuths are in (-pi, pi], as from math.Atan2.
+//
+// The local unit vectors (e_r, e_theta, e_z) and (e_r, e_theta, e_phi)
+// are both right-handed, so they are provided as Frames with N = e_r,
+// and vector components are transformed with Frame.ToLocal and ToGlobal.
+// On the axis, where the azimuth is undefined, it is taken as zero.
+//
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"fmt"
+	"math"
+)
+
+type Cylindrical struct {
+	R, Theta, Z float64
+}
+
+func (c Cylindrical) String() string {
+	return fmt.Sprintf("(r=%0.6f, theta=%0.6f, z=%0.6f)", c.R, c.Theta, c.Z)
+}
+
+func ToCylindrical(p Vector3) Cylindrical {
+	return Cylindrical{R: math.Hypot(p.X, p.Y), Theta: math.Atan2(p.Y, p.X), Z: p.Z}
+}
+
+func (c Cylindrical) Cartesian() Vector3 {
+	s, co := math.Sincos(c.Theta)
+	return Vector3{c.R * co, c.R * s, c.Z}
+}
+
+// Unit vectors e_r, e_theta, e_z at the position.
+func (c Cylindrical) Basis() Frame {
+	s, co := math.Sincos(c.Theta)
+	return Frame{
+		N:  Vector3{co, s, 0.0},
+		T1: Vector3{-s, co, 0.0},
+		T2: Vector3{0.0, 0.0, 1.0},
+	}
+}
+
+type Spherical struct {
+	R, Theta, Phi float64
+}
+
+func (sp Spherical) String() string {
+	return fmt.Sprintf("(r=%0.6f, theta=%0.6f, phi=%0.6f)", sp.R, sp.Theta, sp.Phi)
+}
+
+func ToSpherical(p Vector3) Spherical {
+	rxy := math.Hypot(p.X, p.Y)
+	// Atan2 for the polar angle keeps accuracy near the axis, where acos(z/r) does not.
+	return Spherical{R: p.Norm(), Theta: math.Atan2(rxy, p.Z), Phi: math.Atan2(p.Y, p.X)}
+}
+
+func (sp Spherical) Cartesian() Vector3 {
+	st, ct := math.Sincos(sp.Theta)
+	sf, cf := math.Sincos(sp.Phi)
+	return Vector3{sp.R * st * cf, sp.R * st * sf, sp.R * ct}
+}
+
+// Unit vectors e_r, e_theta, e_phi at the position.
+func (sp Spherical) Basis() Frame {
+	st, ct := math.Sincos(sp.Theta)
+	sf, cf := math.Sincos(sp.Phi)
+	return Frame{
+		N:  Vector3{st * cf, st * sf, ct},
+		T1: Vector3{ct * cf, ct * sf, -st},
+		T2: Vector3{-sf, cf, 0.0},
+	}
+}
+
+// Components (v_r, v_theta, v_z) of the Cartesian vector v at position p,
+// returned in the X, Y, Z fields.
+func VectorToCylindrical(p, v Vector3) Vector3 {
+	return ToCylindrical(p).Basis().ToLocal(v)
+}
+
+// Cartesian vector from components (v_r, v_theta, v_z) at position p.
+func VectorFromCylindrical(p Cylindrical, vc Vector3) Vector3 {
+	return p.Basis().ToGlobal(vc)
+}
+
+// Components (v_r, v_theta, v_phi) of the Cartesian vector v at position p,
+// returned in the X, Y, Z fields.
+func VectorToSpherical(p, v Vector3) Vector3 {
+	return ToSpherical(p).Basis().ToLocal(v)
+}
+
+// Cartesian vector from components (v_r, v_theta, v_phi) at position p.
+func VectorFromSpherical(p Spherical, vs Vector3) Vector3 {
+	return p.Basis().ToGlobal(vs)
+}
@@ -0,0 +1,75 @@
+// coords_test.go
+// Try out the cylindrical and spherical coordinate conversions.
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCylindrical(t *testing.T) {
+	p := Vector3{-1.0, 1.0, 2.0}
+	c := ToCylindrical(p)
+	if math.Abs(c.R-math.Sqrt(2.0)) > 1.0e-12 || math.Abs(c.Theta-0.75*math.Pi) > 1.0e-12 || c.Z != 2.0 {
+		t.Errorf("ToCylindrical error got= %v", c)
+	}
+	if !c.Cartesian().ApproxEquals(p, 1.0e-12) {
+		t.Errorf("Cylindrical round trip error got= %v want= %v", c.Cartesian(), p)
+	}
+	checkFrame(t, c.Basis())
+	// Solid-body rotation about z, v = omega x p, is purely azimuthal
+	// with speed omega.r, plus the axial component.
+	omega := 3.0
+	v := Vector3{0.0, 0.0, omega}.Cross(p).Add(Vector3{0.0, 0.0, 0.5})
+	vc := VectorToCylindrical(p, v)
+	if !vc.ApproxEquals(Vector3{0.0, omega * c.R, 0.5}, 1.0e-12) {
+		t.Errorf("Cylindrical components error got= %v", vc)
+	}
+	if !VectorFromCylindrical(c, vc).ApproxEquals(v, 1.0e-12) {
+		t.Errorf("Cylindrical components round trip error got= %v", VectorFromCylindrical(c, vc))
+	}
+	// On the axis, the azimuth is taken as zero.
+	if ToCylindrical(Vector3{0.0, 0.0, 5.0}).Theta != 0.0 {
+		t.Errorf("On-axis azimuth error")
+	}
+}
+
+func TestSpherical(t *testing.T) {
+	p := Vector3{1.0, 1.0, math.Sqrt(2.0)}
+	sp := ToSpherical(p)
+	if math.Abs(sp.R-2.0) > 1.0e-12 || math.Abs(sp.Theta-math.Pi/4) > 1.0e-12 || math.Abs(sp.Phi-math.Pi/4) > 1.0e-12 {
+		t.Errorf("ToSpherical error got= %v", sp)
+	}
+	if !sp.Cartesian().ApproxEquals(p, 1.0e-12) {
+		t.Errorf("Spherical round trip error got= %v want= %v", sp.Cartesian(), p)
+	}
+	checkFrame(t, sp.Basis())
+	// A radial velocity field, v = p, has only the r component.
+	vs := VectorToSpherical(p, p)
+	if !vs.ApproxEquals(Vector3{2.0, 0.0, 0.0}, 1.0e-12) {
+		t.Errorf("Radial components error got= %v", vs)
+	}
+	// A velocity along -z has components (-cos(theta), sin(theta), 0).
+	vs = VectorToSpherical(p, Vector3{0.0, 0.0, -1.0})
+	s := math.Sqrt(0.5)
+	if !vs.ApproxEquals(Vector3{-s, s, 0.0}, 1.0e-12) {
+		t.Errorf("Spherical components error got= %v", vs)
+	}
+	v := Vector3{0.3, -2.0, 1.1}
+	if !VectorFromSpherical(sp, VectorToSpherical(p, v)).ApproxEquals(v, 1.0e-12) {
+		t.Errorf("Spherical components round trip error")
+	}
+	// Near the pole, the polar angle keeps full relative accuracy.
+	near := ToSpherical(Vector3{1.0e-10, 0.0, 1.0})
+	if math.Abs(near.Theta-1.0e-10) > 1.0e-24 {
+		t.Errorf("Near-polar angle error got= %v", near.Theta)
+	}
+	// Every point of a sphere round-trips, including the poles.
+	for _, q := range []Vector3{{0.0, 0.0, 1.0}, {0.0, 0.0, -1.0}, {-1.0, 0.0, 0.0}, {0.0, -2.0, -3.0}} {
+		if !ToSpherical(q).Cartesian().ApproxEquals(q, 1.0e-12) {
+			t.Errorf("Spherical round trip error at %v", q)
+		}
+	}
+}
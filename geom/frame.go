@@ -0,0 +1,92 @@
+// frame.go
+// Local orthonormal coordinate frames, such as those at the faces of
+// finite-volume cells, with unit normal N and unit tangents T1 and T2.
+//
+// The frames are right-handed, N x T1 = T2, and a vector v has the local
+// components (v.N, v.T1, v.T2), stored in the X, Y, Z fields of a Vector3.
+//
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+type Frame struct {
+	N, T1, T2 Vector3
+}
+
+const frameTol = 1.0e-9
+
+// Checks that the supplied vectors form a right-handed orthonormal basis.
+func NewFrame(n, t1, t2 Vector3) (Frame, error) {
+	f := Frame{n, t1, t2}
+	if math.Abs(n.Norm()-1.0) > frameTol || math.Abs(t1.Norm()-1.0) > frameTol ||
+		math.Abs(t2.Norm()-1.0) > frameTol {
+		return Frame{}, errors.New(fmt.Sprintf("Frame vectors are not of unit length: %v", f))
+	}
+	if math.Abs(n.Dot(t1)) > frameTol || math.Abs(n.Dot(t2)) > frameTol ||
+		math.Abs(t1.Dot(t2)) > frameTol {
+		return Frame{}, errors.New(fmt.Sprintf("Frame vectors are not orthogonal: %v", f))
+	}
+	if n.Cross(t1).Dot(t2) < 0.0 {
+		return Frame{}, errors.New(fmt.Sprintf("Frame is not right-handed: %v", f))
+	}
+	return f, nil
+}
+
+// A right-handed frame with the given normal direction and arbitrary tangents.
+// The tangents are a continuous function of n except where n.Z changes sign,
+// following Duff et al. (2017) Building an orthonormal basis, revisited.
+// JCGT 6(1), which avoids the loss of accuracy in the cross product
+// with a fixed helper axis when n is nearly aligned with it.
+func NewFrameFromNormal(n Vector3) (Frame, error) {
+	if n.Norm() == 0.0 {
+		return Frame{}, errors.New("Frame normal must be nonzero")
+	}
+	n = n.Unit()
+	sign := math.Copysign(1.0, n.Z)
+	a := -1.0 / (sign + n.Z)
+	b := n.X * n.Y * a
+	t1 := Vector3{1.0 + sign*n.X*n.X*a, sign * b, -sign * n.X}
+	t2 := Vector3{b, sign + n.Y*n.Y*a, -n.Y}
+	return Frame{N: n, T1: t1, T2: t2}, nil
+}
+
+// A right-handed frame with the given normal and with the first tangent
+// in the direction of the part of t that is perpendicular to n.
+func NewFrameFromNormalTangent(n, t Vector3) (Frame, error) {
+	if n.Norm() == 0.0 {
+		return Frame{}, errors.New("Frame normal must be nonzero")
+	}
+	n = n.Unit()
+	t1 := t.Reject(n)
+	if t1.Norm() <= frameTol*t.Norm() || t1.Norm() == 0.0 {
+		return Frame{}, errors.New(fmt.Sprintf("Tangent %v is parallel to normal %v", t, n))
+	}
+	t1 = t1.Unit()
+	return Frame{N: n, T1: t1, T2: n.Cross(t1)}, nil
+}
+
+func (f Frame) String() string {
+	return fmt.Sprintf("Frame{N: %v, T1: %v, T2: %v}", f.N, f.T1, f.T2)
+}
+
+// Components of the global vector v in the local frame.
+func (f Frame) ToLocal(v Vector3) Vector3 {
+	return Vector3{v.Dot(f.N), v.Dot(f.T1), v.Dot(f.T2)}
+}
+
+// The global vector with local components v.
+func (f Frame) ToGlobal(v Vector3) Vector3 {
+	return f.N.Mul(v.X).Add(f.T1.Mul(v.Y)).Add(f.T2.Mul(v.Z))
+}
+
+// The rotation matrix that takes global components to local ones,
+// with the frame vectors as its rows. Its transpose goes the other way.
+func (f Frame) Matrix3() Matrix3 {
+	return NewMatrix3FromRows(f.N, f.T1, f.T2)
+}
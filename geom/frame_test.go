@@ -0,0 +1,73 @@
+// frame_test.go
+// Try out the local coordinate frames.
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+func checkFrame(t *testing.T, f Frame) {
+	t.Helper()
+	if _, err := NewFrame(f.N, f.T1, f.T2); err != nil {
+		t.Errorf("Invalid frame: %s", err)
+	}
+}
+
+func TestFrameFromNormal(t *testing.T) {
+	normals := []Vector3{
+		{1.0, 0.0, 0.0}, {0.0, 1.0, 0.0}, {0.0, 0.0, 1.0}, {0.0, 0.0, -1.0},
+		{1.0, 2.0, 3.0}, {-0.3, 0.1, -2.0}, {1.0e-12, 0.0, -1.0}, {1.0, 1.0, 1.0e-14},
+	}
+	for _, n := range normals {
+		f, err := NewFrameFromNormal(n)
+		if err != nil || !f.N.ApproxEquals(n.Unit(), 1.0e-12) {
+			t.Errorf("NewFrameFromNormal error n= %v f= %v err= %v", n, f, err)
+		}
+		checkFrame(t, f)
+	}
+	_, err := NewFrameFromNormal(Vector3{})
+	if err == nil {
+		t.Errorf("Did not detect zero normal.")
+	}
+}
+
+func TestFrameTransforms(t *testing.T) {
+	n := Vector3{1.0, 1.0, 0.0}
+	f, err := NewFrameFromNormalTangent(n, Vector3{0.0, 1.0, 1.0})
+	if err != nil {
+		t.Fatalf("NewFrameFromNormalTangent failed, err: %s", err)
+	}
+	checkFrame(t, f)
+	s := 1.0 / math.Sqrt(2.0)
+	if !f.N.ApproxEquals(Vector3{s, s, 0.0}, 1.0e-12) {
+		t.Errorf("Frame normal error got= %v", f.N)
+	}
+	// A velocity vector, and its normal and tangential parts.
+	v := Vector3{3.0, 1.0, -2.0}
+	vl := f.ToLocal(v)
+	if math.Abs(vl.X-4.0*s) > 1.0e-12 || math.Abs(vl.Norm()-v.Norm()) > 1.0e-12 {
+		t.Errorf("Frame ToLocal error got= %v", vl)
+	}
+	if !f.ToGlobal(vl).ApproxEquals(v, 1.0e-12) {
+		t.Errorf("Frame round trip error got= %v want= %v", f.ToGlobal(vl), v)
+	}
+	if !f.Matrix3().MulVec(v).ApproxEquals(vl, 1.0e-12) ||
+		!f.Matrix3().Transpose().MulVec(vl).ApproxEquals(v, 1.0e-12) {
+		t.Errorf("Frame Matrix3 error")
+	}
+	_, err = NewFrameFromNormalTangent(n, n.Mul(2.0))
+	if err == nil {
+		t.Errorf("Did not detect tangent parallel to normal.")
+	}
+	_, err = NewFrame(Vector3{0.0, 0.0, 1.0}, Vector3{0.0, 1.0, 0.0}, Vector3{1.0, 0.0, 0.0})
+	if err == nil {
+		t.Errorf("Did not detect left-handed frame.")
+	}
+	_, err = NewFrame(Vector3{0.0, 0.0, 1.0}, Vector3{0.0, 1.0, 0.1}, Vector3{1.0, 0.0, 0.0})
+	if err == nil {
+		t.Errorf("Did not detect non-orthonormal frame.")
+	}
+}
@@ -0,0 +1,163 @@
+// grid.go
+// Structured grids of vertices, generated by transfinite interpolation
+// over the boundaries of 2-D and 3-D parametric regions.
+//
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Parametric volume, p(r, s, t) for 0 <= r, s, t <= 1.
+type Volume interface {
+	Eval(r, s, t float64) Vector3
+}
+
+// Transfinite interpolation within the twelve edges of a hexahedral region.
+// With corner points pXYZ, for X, Y, Z in {0, 1} the values of r, s, t,
+// the edges are indexed by the other two parameters, so that
+//
+//	R[a+2b] runs in r from p0ab to p1ab,
+//	S[a+2b] runs in s from pa0b to pa1b,
+//	T[a+2b] runs in t from pab0 to pab1.
+//
+// The interpolant is the sum of the edge blends in each direction less twice
+// the trilinear blend of the corners, which reproduces all twelve edges.
+type TFIVolume struct {
+	R, S, T [4]Path
+	corner  [2][2][2]Vector3
+}
+
+func NewTFIVolume(r, s, t [4]Path) (*TFIVolume, error) {
+	v := TFIVolume{R: r, S: s, T: t}
+	for b := 0; b < 2; b++ {
+		for a := 0; a < 2; a++ {
+			v.corner[0][a][b] = r[a+2*b].Eval(0.0)
+			v.corner[1][a][b] = r[a+2*b].Eval(1.0)
+		}
+	}
+	size := v.corner[0][0][0].Distance(v.corner[1][1][1])
+	tol := 1.0e-9 * (1.0 + size)
+	for b := 0; b < 2; b++ {
+		for a := 0; a < 2; a++ {
+			checks := [][2]Vector3{
+				{s[a+2*b].Eval(0.0), v.corner[a][0][b]},
+				{s[a+2*b].Eval(1.0), v.corner[a][1][b]},
+				{t[a+2*b].Eval(0.0), v.corner[a][b][0]},
+				{t[a+2*b].Eval(1.0), v.corner[a][b][1]},
+			}
+			for _, c := range checks {
+				if c[0].Distance(c[1]) > tol {
+					msg := fmt.Sprintf("Edges do not meet at corner %v, found %v", c[1], c[0])
+					return nil, errors.New(msg)
+				}
+			}
+		}
+	}
+	return &v, nil
+}
+
+func (v *TFIVolume) Eval(r, s, t float64) Vector3 {
+	wr := [2]float64{1.0 - r, r}
+	ws := [2]float64{1.0 - s, s}
+	wt := [2]float64{1.0 - t, t}
+	var p, tri Vector3
+	for b := 0; b < 2; b++ {
+		for a := 0; a < 2; a++ {
+			p = p.Add(v.R[a+2*b].Eval(r).Mul(ws[a] * wt[b]))
+			p = p.Add(v.S[a+2*b].Eval(s).Mul(wr[a] * wt[b]))
+			p = p.Add(v.T[a+2*b].Eval(t).Mul(wr[a] * ws[b]))
+		}
+	}
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			for k := 0; k < 2; k++ {
+				tri = tri.Add(v.corner[i][j][k].Mul(wr[i] * ws[j] * wt[k]))
+			}
+		}
+	}
+	return p.Sub(tri.Mul(2.0))
+}
+
+//-----------------------------------------------------------------------------
+// Structured grid
+
+// Vertices stored with i varying fastest, as VTK expects.
+// A 2-D grid has NK = 1.
+type StructuredGrid struct {
+	NI, NJ, NK int
+	Vertices   []Vector3
+}
+
+func (g *StructuredGrid) index(i, j, k int) int {
+	return i + g.NI*(j+g.NJ*k)
+}
+
+func (g *StructuredGrid) At(i, j, k int) Vector3 {
+	return g.Vertices[g.index(i, j, k)]
+}
+
+// Node parameters in [0, 1], with nil meaning evenly spaced.
+func clusteredParams(n int, c ClusterFunction) []float64 {
+	if c == nil {
+		c = LinearCluster{}
+	}
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = c.Eval(float64(i) / float64(n-1))
+	}
+	x[0], x[n-1] = 0.0, 1.0
+	return x
+}
+
+// Grid of ni x nj vertices over the surface, with the nodes
+// distributed along r and s by the clustering functions.
+func NewStructuredGrid2D(surf Surface, ni, nj int, clusterR, clusterS ClusterFunction) (*StructuredGrid, error) {
+	if ni < 2 || nj < 2 {
+		return nil, errors.New(fmt.Sprintf("Need at least 2 vertices each way, found %dx%d", ni, nj))
+	}
+	g := StructuredGrid{NI: ni, NJ: nj, NK: 1, Vertices: make([]Vector3, ni*nj)}
+	rs := clusteredParams(ni, clusterR)
+	ss := clusteredParams(nj, clusterS)
+	for j := 0; j < nj; j++ {
+		for i := 0; i < ni; i++ {
+			g.Vertices[g.index(i, j, 0)] = surf.Eval(rs[i], ss[j])
+		}
+	}
+	return &g, nil
+}
+
+// Grid of ni x nj vertices by transfinite interpolation over the
+// four boundary paths, as for NewCoonsPatch.
+func NewStructuredGridTFI2D(north, east, south, west Path, ni, nj int,
+	clusterR, clusterS ClusterFunction) (*StructuredGrid, error) {
+	patch, err := NewCoonsPatch(north, east, south, west)
+	if err != nil {
+		return nil, err
+	}
+	return NewStructuredGrid2D(patch, ni, nj, clusterR, clusterS)
+}
+
+// Grid of ni x nj x nk vertices within the volume.
+func NewStructuredGrid3D(vol Volume, ni, nj, nk int,
+	clusterR, clusterS, clusterT ClusterFunction) (*StructuredGrid, error) {
+	if ni < 2 || nj < 2 || nk < 2 {
+		msg := fmt.Sprintf("Need at least 2 vertices each way, found %dx%dx%d", ni, nj, nk)
+		return nil, errors.New(msg)
+	}
+	g := StructuredGrid{NI: ni, NJ: nj, NK: nk, Vertices: make([]Vector3, ni*nj*nk)}
+	rs := clusteredParams(ni, clusterR)
+	ss := clusteredParams(nj, clusterS)
+	ts := clusteredParams(nk, clusterT)
+	for k := 0; k < nk; k++ {
+		for j := 0; j < nj; j++ {
+			for i := 0; i < ni; i++ {
+				g.Vertices[g.index(i, j, k)] = vol.Eval(rs[i], ss[j], ts[k])
+			}
+		}
+	}
+	return &g, nil
+}
@@ -0,0 +1,136 @@
+// grid_test.go
+// Try out the structured-grid generation and VTK output.
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"bytes"
+	"encoding/xml"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestGrid2D(t *testing.T) {
+	p00, p10 := Vector3{0.0, 0.0, 0.0}, Vector3{2.0, 0.0, 0.0}
+	p01, p11 := Vector3{0.0, 1.0, 0.0}, Vector3{2.0, 1.0, 0.0}
+	// A bump on the north wall.
+	north, _ := NewBezier([]Vector3{p01, {1.0, 1.5, 0.0}, p11})
+	clusterS, _ := NewRobertsCluster(true, false, 1.1)
+	g, err := NewStructuredGridTFI2D(north, Line{p10, p11}, Line{p00, p10}, Line{p00, p01}, 5, 4, nil, clusterS)
+	if err != nil {
+		t.Fatalf("NewStructuredGridTFI2D failed, err: %s", err)
+	}
+	if g.NI != 5 || g.NJ != 4 || g.NK != 1 || len(g.Vertices) != 20 {
+		t.Errorf("Grid size error %dx%dx%d", g.NI, g.NJ, g.NK)
+	}
+	if !g.At(4, 3, 0).ApproxEquals(p11, 1.0e-12) || !g.At(2, 3, 0).ApproxEquals(north.Eval(0.5), 1.0e-12) ||
+		!g.At(1, 0, 0).ApproxEquals(Vector3{0.5, 0.0, 0.0}, 1.0e-12) {
+		t.Errorf("Grid boundary vertices error")
+	}
+	// Clustered toward the south wall.
+	if g.At(0, 1, 0).Y >= 1.0/3.0 {
+		t.Errorf("Grid clustering error y= %v", g.At(0, 1, 0).Y)
+	}
+	_, err = NewStructuredGridTFI2D(north, Line{p10, p11}, Line{p00, p10}, Line{p00, p01}, 1, 3, nil, nil)
+	if err == nil {
+		t.Errorf("Did not detect too few vertices.")
+	}
+}
+
+func unitCubeEdges() (r, s, t [4]Path) {
+	for b := 0; b < 2; b++ {
+		for a := 0; a < 2; a++ {
+			fa, fb := float64(a), float64(b)
+			r[a+2*b] = Line{Vector3{0.0, fa, fb}, Vector3{1.0, fa, fb}}
+			s[a+2*b] = Line{Vector3{fa, 0.0, fb}, Vector3{fa, 1.0, fb}}
+			t[a+2*b] = Line{Vector3{fa, fb, 0.0}, Vector3{fa, fb, 1.0}}
+		}
+	}
+	return r, s, t
+}
+
+func TestGrid3D(t *testing.T) {
+	r, s, tt := unitCubeEdges()
+	// Bend one edge, which the interpolant must reproduce.
+	bent, _ := NewBezier([]Vector3{{0.0, 1.0, 1.0}, {0.5, 1.5, 1.5}, {1.0, 1.0, 1.0}})
+	r[3] = bent
+	vol, err := NewTFIVolume(r, s, tt)
+	if err != nil {
+		t.Fatalf("NewTFIVolume failed, err: %s", err)
+	}
+	for _, x := range []float64{0.0, 0.3, 0.5, 1.0} {
+		if !vol.Eval(x, 1.0, 1.0).ApproxEquals(bent.Eval(x), 1.0e-12) ||
+			!vol.Eval(x, 0.0, 1.0).ApproxEquals(Vector3{x, 0.0, 1.0}, 1.0e-12) ||
+			!vol.Eval(1.0, x, 0.0).ApproxEquals(Vector3{1.0, x, 0.0}, 1.0e-12) {
+			t.Errorf("TFI volume does not reproduce edges at %v", x)
+		}
+	}
+	r, s, tt = unitCubeEdges()
+	cube, _ := NewTFIVolume(r, s, tt)
+	if !cube.Eval(0.2, 0.5, 0.7).ApproxEquals(Vector3{0.2, 0.5, 0.7}, 1.0e-12) {
+		t.Errorf("TFI unit cube error got= %v", cube.Eval(0.2, 0.5, 0.7))
+	}
+	g, err := NewStructuredGrid3D(cube, 3, 4, 5, nil, nil, nil)
+	if err != nil || len(g.Vertices) != 60 || !g.At(2, 3, 4).ApproxEquals(Vector3{1.0, 1.0, 1.0}, 1.0e-12) ||
+		!g.At(1, 2, 1).ApproxEquals(Vector3{0.5, 2.0 / 3.0, 0.25}, 1.0e-12) {
+		t.Errorf("3-D grid error err= %v", err)
+	}
+	// The cells of the grid fill the cube.
+	total := 0.0
+	for k := 0; k < g.NK-1; k++ {
+		for j := 0; j < g.NJ-1; j++ {
+			for i := 0; i < g.NI-1; i++ {
+				_, v := HexProperties([8]Vector3{
+					g.At(i, j, k), g.At(i+1, j, k), g.At(i+1, j+1, k), g.At(i, j+1, k),
+					g.At(i, j, k+1), g.At(i+1, j, k+1), g.At(i+1, j+1, k+1), g.At(i, j+1, k+1),
+				})
+				total += v
+			}
+		}
+	}
+	if math.Abs(total-1.0) > 1.0e-12 {
+		t.Errorf("Grid cell volumes sum to %v, want 1.0", total)
+	}
+
+	s[0] = Line{Vector3{0.0, 0.0, 0.0}, Vector3{0.0, 1.0, 0.1}}
+	_, err = NewTFIVolume(r, s, tt)
+	if err == nil {
+		t.Errorf("Did not detect edges that do not meet.")
+	}
+}
+
+func TestVTKOutput(t *testing.T) {
+	r, s, tt := unitCubeEdges()
+	cube, _ := NewTFIVolume(r, s, tt)
+	g, _ := NewStructuredGrid3D(cube, 2, 3, 2, nil, nil, nil)
+	var b bytes.Buffer
+	err := g.WriteVTK(&b, "unit\ncube")
+	if err != nil {
+		t.Fatalf("WriteVTK failed, err: %s", err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 6+12 || lines[1] != "unit cube" || lines[4] != "DIMENSIONS 2 3 2" ||
+		lines[5] != "POINTS 12 double" || lines[6] != "0 0 0" || lines[17] != "1 1 1" {
+		t.Errorf("Legacy VTK output error:\n%s", b.String())
+	}
+
+	b.Reset()
+	err = g.WriteVTS(&b)
+	if err != nil {
+		t.Fatalf("WriteVTS failed, err: %s", err)
+	}
+	var doc struct {
+		Type  string `xml:"type,attr"`
+		Piece struct {
+			Extent string `xml:"Extent,attr"`
+			Data   string `xml:"Points>DataArray"`
+		} `xml:"StructuredGrid>Piece"`
+	}
+	err = xml.Unmarshal(b.Bytes(), &doc)
+	if err != nil || doc.Type != "StructuredGrid" || doc.Piece.Extent != "0 1 0 2 0 1" ||
+		len(strings.Fields(doc.Piece.Data)) != 36 {
+		t.Errorf("XML VTK output error err= %v:\n%s", err, b.String())
+	}
+}
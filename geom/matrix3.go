@@ -0,0 +1,233 @@
+// matrix3.go
+// A 3x3 matrix to go with Vector3, mainly for rotations of rigid bodies.
+//
+// The rotation constructors build active rotations, that is,
+// R.MulVec(v) rotates the vector v counter-clockwise (right-handed)
+// about the axis, within a fixed coordinate system.
+//
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Elements are indexed as m[row][col].
+type Matrix3 [3][3]float64
+
+func Identity3() Matrix3 {
+	return Matrix3{{1.0, 0.0, 0.0}, {0.0, 1.0, 0.0}, {0.0, 0.0, 1.0}}
+}
+
+func NewMatrix3FromRows(r0, r1, r2 Vector3) Matrix3 {
+	return Matrix3{{r0.X, r0.Y, r0.Z}, {r1.X, r1.Y, r1.Z}, {r2.X, r2.Y, r2.Z}}
+}
+
+func NewMatrix3FromCols(c0, c1, c2 Vector3) Matrix3 {
+	return Matrix3{{c0.X, c1.X, c2.X}, {c0.Y, c1.Y, c2.Y}, {c0.Z, c1.Z, c2.Z}}
+}
+
+func (m Matrix3) Row(i int) Vector3 {
+	return Vector3{m[i][0], m[i][1], m[i][2]}
+}
+
+func (m Matrix3) Col(j int) Vector3 {
+	return Vector3{m[0][j], m[1][j], m[2][j]}
+}
+
+func (m Matrix3) String() string {
+	return fmt.Sprintf("[%v, %v, %v]", m.Row(0), m.Row(1), m.Row(2))
+}
+
+func (m Matrix3) ApproxEquals(other Matrix3, tol float64) bool {
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if math.Abs(m[i][j]-other[i][j]) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (m Matrix3) Add(other Matrix3) Matrix3 {
+	var z Matrix3
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			z[i][j] = m[i][j] + other[i][j]
+		}
+	}
+	return z
+}
+
+func (m Matrix3) Sub(other Matrix3) Matrix3 {
+	var z Matrix3
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			z[i][j] = m[i][j] - other[i][j]
+		}
+	}
+	return z
+}
+
+func (m Matrix3) Scale(s float64) Matrix3 {
+	var z Matrix3
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			z[i][j] = s * m[i][j]
+		}
+	}
+	return z
+}
+
+// Matrix product m.other
+func (m Matrix3) Mul(other Matrix3) Matrix3 {
+	var z Matrix3
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			z[i][j] = m[i][0]*other[0][j] + m[i][1]*other[1][j] + m[i][2]*other[2][j]
+		}
+	}
+	return z
+}
+
+// Matrix-vector product m.v
+func (m Matrix3) MulVec(v Vector3) Vector3 {
+	return Vector3{
+		m[0][0]*v.X + m[0][1]*v.Y + m[0][2]*v.Z,
+		m[1][0]*v.X + m[1][1]*v.Y + m[1][2]*v.Z,
+		m[2][0]*v.X + m[2][1]*v.Y + m[2][2]*v.Z,
+	}
+}
+
+func (m Matrix3) Transpose() Matrix3 {
+	var z Matrix3
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			z[i][j] = m[j][i]
+		}
+	}
+	return z
+}
+
+func (m Matrix3) Trace() float64 {
+	return m[0][0] + m[1][1] + m[2][2]
+}
+
+func (m Matrix3) Det() float64 {
+	return m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+}
+
+func (m Matrix3) normInf() float64 {
+	norm := 0.0
+	for i := 0; i < 3; i++ {
+		norm = math.Max(norm, math.Abs(m[i][0])+math.Abs(m[i][1])+math.Abs(m[i][2]))
+	}
+	return norm
+}
+
+// Inverse via the adjugate, with the matrix taken to be singular
+// if the determinant is negligible relative to the size of the elements.
+func (m Matrix3) Inverse() (Matrix3, error) {
+	det := m.Det()
+	scale := m.normInf()
+	if math.Abs(det) <= 1.0e-14*scale*scale*scale {
+		return Matrix3{}, errors.New(fmt.Sprintf("Singular matrix, det= %g", det))
+	}
+	// The rows of the inverse are the cross products of the columns.
+	c0, c1, c2 := m.Col(0), m.Col(1), m.Col(2)
+	return NewMatrix3FromRows(c1.Cross(c2), c2.Cross(c0), c0.Cross(c1)).Scale(1.0 / det), nil
+}
+
+//-----------------------------------------------------------------------------
+// Rotations
+
+func RotationX(angle float64) Matrix3 {
+	s, c := math.Sincos(angle)
+	return Matrix3{{1.0, 0.0, 0.0}, {0.0, c, -s}, {0.0, s, c}}
+}
+
+func RotationY(angle float64) Matrix3 {
+	s, c := math.Sincos(angle)
+	return Matrix3{{c, 0.0, s}, {0.0, 1.0, 0.0}, {-s, 0.0, c}}
+}
+
+func RotationZ(angle float64) Matrix3 {
+	s, c := math.Sincos(angle)
+	return Matrix3{{c, -s, 0.0}, {s, c, 0.0}, {0.0, 0.0, 1.0}}
+}
+
+// Rotation by angle (radians) about the axis, using Rodrigues' formula.
+// The axis need not be of unit length but must be nonzero.
+func RotationAxisAngle(axis Vector3, angle float64) (Matrix3, error) {
+	if axis.Norm() == 0.0 {
+		return Matrix3{}, errors.New("Rotation axis must be nonzero")
+	}
+	n := axis.Unit()
+	s, c := math.Sincos(angle)
+	t := 1.0 - c
+	return Matrix3{
+		{c + t*n.X*n.X, t*n.X*n.Y - s*n.Z, t*n.X*n.Z + s*n.Y},
+		{t*n.Y*n.X + s*n.Z, c + t*n.Y*n.Y, t*n.Y*n.Z - s*n.X},
+		{t*n.Z*n.X - s*n.Y, t*n.Z*n.Y + s*n.X, c + t*n.Z*n.Z},
+	}, nil
+}
+
+// The sequence of axes for Euler angles, for example "ZYX" or "ZXZ",
+// and whether the rotations are about the body axes as they move
+// (intrinsic, the zero value) or about the fixed axes (extrinsic).
+type EulerConvention struct {
+	Sequence  string
+	Extrinsic bool
+}
+
+var (
+	// Aerospace yaw, pitch, roll: intrinsic z-y'-x''.
+	EulerYawPitchRoll = EulerConvention{Sequence: "ZYX"}
+	// Classical z-x'-z'' convention of Goldstein.
+	EulerClassical = EulerConvention{Sequence: "ZXZ"}
+)
+
+// The validated sequence, in upper case.
+func (conv EulerConvention) axes() (string, error) {
+	seq := strings.ToUpper(conv.Sequence)
+	if len(seq) != 3 || strings.Trim(seq, "XYZ") != "" || seq[0] == seq[1] || seq[1] == seq[2] {
+		msg := fmt.Sprintf("Invalid Euler sequence %q", conv.Sequence)
+		return "", errors.New(msg)
+	}
+	return seq, nil
+}
+
+func axisRotation(axis byte, angle float64) Matrix3 {
+	switch axis {
+	case 'X':
+		return RotationX(angle)
+	case 'Y':
+		return RotationY(angle)
+	}
+	return RotationZ(angle)
+}
+
+// Rotation by the angles a1, a2, a3 about the successive axes of the convention.
+// For intrinsic rotations R = R1(a1).R2(a2).R3(a3) and for extrinsic rotations
+// R = R3(a3).R2(a2).R1(a1), so intrinsic "ZYX" is the same as extrinsic "XYZ"
+// with the angles listed in reverse order.
+func RotationEuler(a1, a2, a3 float64, conv EulerConvention) (Matrix3, error) {
+	seq, err := conv.axes()
+	if err != nil {
+		return Matrix3{}, err
+	}
+	r1 := axisRotation(seq[0], a1)
+	r2 := axisRotation(seq[1], a2)
+	r3 := axisRotation(seq[2], a3)
+	if conv.Extrinsic {
+		return r3.Mul(r2).Mul(r1), nil
+	}
+	return r1.Mul(r2).Mul(r3), nil
+}
@@ -0,0 +1,85 @@
+// matrix3_test.go
+// Try out the Matrix3 functions and rotations.
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMatrix3(t *testing.T) {
+	m := Matrix3{{2.0, 1.0, 0.0}, {1.0, 3.0, 1.0}, {0.0, 1.0, 4.0}}
+	if math.Abs(m.Det()-18.0) > 1.0e-12 {
+		t.Errorf("Matrix3 Det error got= %v want= 18.0", m.Det())
+	}
+	mi, err := m.Inverse()
+	if err != nil || !m.Mul(mi).ApproxEquals(Identity3(), 1.0e-12) {
+		t.Errorf("Matrix3 Inverse error mi= %v err= %v", mi, err)
+	}
+	v := Vector3{1.0, 2.0, 3.0}
+	if !m.MulVec(v).ApproxEquals(Vector3{4.0, 10.0, 14.0}, 1.0e-12) {
+		t.Errorf("Matrix3 MulVec error got= %v", m.MulVec(v))
+	}
+	a := Matrix3{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}, {7.0, 8.0, 9.0}}
+	if a.Transpose().Row(0) != a.Col(0) || a.Transpose().Transpose() != a || a.Trace() != 15.0 {
+		t.Errorf("Matrix3 Transpose error got= %v", a.Transpose())
+	}
+	if !a.Add(a).Sub(a.Scale(2.0)).ApproxEquals(Matrix3{}, 0.0) {
+		t.Errorf("Matrix3 Add/Sub/Scale error")
+	}
+	_, err = a.Inverse()
+	if err == nil {
+		t.Errorf("Did not detect singular matrix.")
+	}
+}
+
+func TestRotations(t *testing.T) {
+	x := Vector3{1.0, 0.0, 0.0}
+	y := Vector3{0.0, 1.0, 0.0}
+	z := Vector3{0.0, 0.0, 1.0}
+	if !RotationZ(math.Pi/2).MulVec(x).ApproxEquals(y, 1.0e-12) ||
+		!RotationX(math.Pi/2).MulVec(y).ApproxEquals(z, 1.0e-12) ||
+		!RotationY(math.Pi/2).MulVec(z).ApproxEquals(x, 1.0e-12) {
+		t.Errorf("Elementary rotation error")
+	}
+	// A third of a turn about the diagonal permutes the axes.
+	r, err := RotationAxisAngle(Vector3{1.0, 1.0, 1.0}, 2*math.Pi/3)
+	if err != nil || !r.MulVec(x).ApproxEquals(y, 1.0e-12) || !r.MulVec(y).ApproxEquals(z, 1.0e-12) {
+		t.Errorf("Axis-angle rotation error r= %v err= %v", r, err)
+	}
+	if !r.Mul(r.Transpose()).ApproxEquals(Identity3(), 1.0e-12) || math.Abs(r.Det()-1.0) > 1.0e-12 {
+		t.Errorf("Rotation is not proper orthogonal r= %v", r)
+	}
+	r2, _ := RotationAxisAngle(z, 0.3)
+	if !r2.ApproxEquals(RotationZ(0.3), 1.0e-12) {
+		t.Errorf("Axis-angle about z error r2= %v", r2)
+	}
+	_, err = RotationAxisAngle(Vector3{}, 1.0)
+	if err == nil {
+		t.Errorf("Did not detect zero rotation axis.")
+	}
+
+	yaw, pitch, roll := 0.3, -0.2, 0.1
+	r3, err := RotationEuler(yaw, pitch, roll, EulerYawPitchRoll)
+	r3ref := RotationZ(yaw).Mul(RotationY(pitch)).Mul(RotationX(roll))
+	if err != nil || !r3.ApproxEquals(r3ref, 1.0e-12) {
+		t.Errorf("Yaw-pitch-roll rotation error r3= %v err= %v", r3, err)
+	}
+	r4, _ := RotationEuler(roll, pitch, yaw, EulerConvention{Sequence: "XYZ", Extrinsic: true})
+	if !r4.ApproxEquals(r3, 1.0e-12) {
+		t.Errorf("Extrinsic XYZ should match intrinsic ZYX r4= %v", r4)
+	}
+	r5, _ := RotationEuler(0.5, 0.4, 0.3, EulerClassical)
+	r5ref := RotationZ(0.5).Mul(RotationX(0.4)).Mul(RotationZ(0.3))
+	if !r5.ApproxEquals(r5ref, 1.0e-12) {
+		t.Errorf("Classical Euler rotation error r5= %v", r5)
+	}
+	for _, seq := range []string{"ZZX", "XY", "ABC"} {
+		_, err = RotationEuler(0.1, 0.2, 0.3, EulerConvention{Sequence: seq})
+		if err == nil {
+			t.Errorf("Did not detect invalid Euler sequence %q", seq)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+// nurbs.go
+// B-spline and NURBS curves and tensor-product surfaces.
+//
+// A B-spline is the special case of a NURBS with all weights equal to one,
+// so the one type serves for both. Evaluation is by de Boor's algorithm on
+// the homogeneous control points (w.x, w.y, w.z, w), following
+// L. Piegl and W. Tiller (1997) The NURBS Book, 2nd edition, Springer.
+//
+// The path parameter t in [0, 1] is mapped onto the valid part of the knot
+// vector, [Knots[Degree], Knots[len(Points)]], so that the knot values
+// themselves may be in any units.
+//
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"errors"
+	"fmt"
+)
+
+type homog [4]float64
+
+func toHomog(p Vector3, w float64) homog {
+	return homog{w * p.X, w * p.Y, w * p.Z, w}
+}
+
+func (h homog) point() Vector3 {
+	return Vector3{h[0] / h[3], h[1] / h[3], h[2] / h[3]}
+}
+
+// Clamped, uniform knots for n control points and degree p, on [0, 1].
+func clampedKnots(n, p int) []float64 {
+	knots := make([]float64, n+p+1)
+	nInterior := n - p - 1
+	for i := range knots {
+		switch {
+		case i <= p:
+			knots[i] = 0.0
+		case i >= n:
+			knots[i] = 1.0
+		default:
+			knots[i] = float64(i-p) / float64(nInterior+1)
+		}
+	}
+	return knots
+}
+
+func checkKnots(n, p int, knots []float64) error {
+	if p < 1 {
+		return errors.New(fmt.Sprintf("Degree must be at least 1, found %d", p))
+	}
+	if n < p+1 {
+		msg := fmt.Sprintf("Need at least %d control points for degree %d, found %d", p+1, p, n)
+		return errors.New(msg)
+	}
+	if len(knots) != n+p+1 {
+		msg := fmt.Sprintf("Need %d knots for %d points of degree %d, found %d", n+p+1, n, p, len(knots))
+		return errors.New(msg)
+	}
+	for i := 1; i < len(knots); i++ {
+		if knots[i] < knots[i-1] {
+			return errors.New(fmt.Sprintf("Knots must be nondecreasing: %v", knots))
+		}
+	}
+	if knots[p] >= knots[n] {
+		return errors.New(fmt.Sprintf("Knots give an empty parameter range: %v", knots))
+	}
+	return nil
+}
+
+func checkWeights(weights []float64, n int) error {
+	if len(weights) != n {
+		return errors.New(fmt.Sprintf("Need %d weights, found %d", n, len(weights)))
+	}
+	for _, w := range weights {
+		if !(w > 0.0) {
+			return errors.New(fmt.Sprintf("Weights must be positive: %v", weights))
+		}
+	}
+	return nil
+}
+
+// de Boor's algorithm, for degree p, at knot value u.
+func deBoor(p int, knots []float64, ctrl []homog, u float64) homog {
+	n := len(ctrl)
+	// Span k with knots[k] <= u < knots[k+1], restricted to the valid range.
+	k := p
+	for k < n-1 && u >= knots[k+1] {
+		k++
+	}
+	d := make([]homog, p+1)
+	copy(d, ctrl[k-p:k+1])
+	for r := 1; r <= p; r++ {
+		for j := p; j >= r; j-- {
+			denom := knots[j+1+k-r] - knots[j+k-p]
+			alpha := 0.0
+			if denom != 0.0 {
+				alpha = (u - knots[j+k-p]) / denom
+			}
+			for c := 0; c < 4; c++ {
+				d[j][c] = (1.0-alpha)*d[j-1][c] + alpha*d[j][c]
+			}
+		}
+	}
+	return d[p]
+}
+
+// Control points and knots of the derivative, a spline of degree p-1.
+func derivSpline(p int, knots []float64, ctrl []homog) ([]float64, []homog) {
+	n := len(ctrl)
+	q := make([]homog, n-1)
+	for i := 0; i < n-1; i++ {
+		denom := knots[i+p+1] - knots[i+1]
+		if denom == 0.0 {
+			continue
+		}
+		for c := 0; c < 4; c++ {
+			q[i][c] = float64(p) * (ctrl[i+1][c] - ctrl[i][c]) / denom
+		}
+	}
+	return knots[1 : len(knots)-1], q
+}
+
+//-----------------------------------------------------------------------------
+// Curves
+
+// The fields may be edited between evaluations, for example to move
+// control points, since the homogeneous points are formed from them
+// on each call. The lengths must stay consistent, as checked by NewNURBS.
+type NURBS struct {
+	Points  []Vector3
+	Weights []float64
+	Degree  int
+	Knots   []float64
+}
+
+// With nil knots, a clamped uniform knot vector is used,
+// so that the curve passes through its first and last points.
+func NewNURBS(points []Vector3, weights []float64, degree int, knots []float64) (*NURBS, error) {
+	if knots == nil && degree >= 1 && len(points) >= degree+1 {
+		knots = clampedKnots(len(points), degree)
+	}
+	if err := checkKnots(len(points), degree, knots); err != nil {
+		return nil, err
+	}
+	if err := checkWeights(weights, len(points)); err != nil {
+		return nil, err
+	}
+	c := NURBS{
+		Points:  append([]Vector3(nil), points...),
+		Weights: append([]float64(nil), weights...),
+		Degree:  degree,
+		Knots:   append([]float64(nil), knots...),
+	}
+	return &c, nil
+}
+
+func homogRow(points []Vector3, weights []float64) []homog {
+	ctrl := make([]homog, len(points))
+	for i, pt := range points {
+		ctrl[i] = toHomog(pt, weights[i])
+	}
+	return ctrl
+}
+
+// A nonrational B-spline, with unit weights.
+func NewBSpline(points []Vector3, degree int, knots []float64) (*NURBS, error) {
+	weights := make([]float64, len(points))
+	for i := range weights {
+		weights[i] = 1.0
+	}
+	return NewNURBS(points, weights, degree, knots)
+}
+
+func (c *NURBS) knotRange() (float64, float64) {
+	return c.Knots[c.Degree], c.Knots[len(c.Points)]
+}
+
+func (c *NURBS) Eval(t float64) Vector3 {
+	u0, u1 := c.knotRange()
+	ctrl := homogRow(c.Points, c.Weights)
+	return deBoor(c.Degree, c.Knots, ctrl, u0+t*(u1-u0)).point()
+}
+
+// For C = A/w in homogeneous form, C' = (A' - w'.C)/w.
+func (c *NURBS) Deriv(t float64) Vector3 {
+	u0, u1 := c.knotRange()
+	u := u0 + t*(u1-u0)
+	ctrl := homogRow(c.Points, c.Weights)
+	h := deBoor(c.Degree, c.Knots, ctrl, u)
+	dKnots, dCtrl := derivSpline(c.Degree, c.Knots, ctrl)
+	dh := deBoor(c.Degree-1, dKnots, dCtrl, u)
+	pt := h.point()
+	dA := Vector3{dh[0], dh[1], dh[2]}
+	return dA.Sub(pt.Mul(dh[3])).Div(h[3]).Mul(u1 - u0)
+}
+
+//-----------------------------------------------------------------------------
+// Surfaces
+
+// Tensor-product surface, with Points[i][j] for i along r and j along s.
+// As for NURBS, the fields may be edited between evaluations.
+type NURBSSurface struct {
+	Points           [][]Vector3
+	Weights          [][]float64
+	DegreeR, DegreeS int
+	KnotsR, KnotsS   []float64
+}
+
+// With nil weights, all weights are one, giving a B-spline surface,
+// and with nil knots, clamped uniform knot vectors are used.
+func NewNURBSSurface(points [][]Vector3, weights [][]float64, degreeR, degreeS int,
+	knotsR, knotsS []float64) (*NURBSSurface, error) {
+	nr := len(points)
+	if nr == 0 || len(points[0]) == 0 {
+		return nil, errors.New("Surface needs control points")
+	}
+	ns := len(points[0])
+	if knotsR == nil && degreeR >= 1 && nr >= degreeR+1 {
+		knotsR = clampedKnots(nr, degreeR)
+	}
+	if knotsS == nil && degreeS >= 1 && ns >= degreeS+1 {
+		knotsS = clampedKnots(ns, degreeS)
+	}
+	if err := checkKnots(nr, degreeR, knotsR); err != nil {
+		return nil, errors.New(fmt.Sprintf("r direction: %s", err))
+	}
+	if err := checkKnots(ns, degreeS, knotsS); err != nil {
+		return nil, errors.New(fmt.Sprintf("s direction: %s", err))
+	}
+	if weights != nil && len(weights) != nr {
+		return nil, errors.New(fmt.Sprintf("Need %d rows of weights, found %d", nr, len(weights)))
+	}
+	surf := NURBSSurface{DegreeR: degreeR, DegreeS: degreeS,
+		KnotsR: append([]float64(nil), knotsR...), KnotsS: append([]float64(nil), knotsS...)}
+	surf.Points = make([][]Vector3, nr)
+	surf.Weights = make([][]float64, nr)
+	for i := 0; i < nr; i++ {
+		if len(points[i]) != ns {
+			msg := fmt.Sprintf("Ragged control net, row %d has %d points, expected %d", i, len(points[i]), ns)
+			return nil, errors.New(msg)
+		}
+		w := make([]float64, ns)
+		if weights == nil {
+			for j := range w {
+				w[j] = 1.0
+			}
+		} else {
+			if err := checkWeights(weights[i], ns); err != nil {
+				return nil, errors.New(fmt.Sprintf("Row %d: %s", i, err))
+			}
+			copy(w, weights[i])
+		}
+		surf.Points[i] = append([]Vector3(nil), points[i]...)
+		surf.Weights[i] = w
+	}
+	return &surf, nil
+}
+
+// Tensor-product Bezier patch, of degree one less than the
+// number of control points in each direction.
+func NewBezierPatch(points [][]Vector3) (*NURBSSurface, error) {
+	if len(points) < 2 || len(points[0]) < 2 {
+		return nil, errors.New("Bezier patch needs at least 2x2 control points")
+	}
+	return NewNURBSSurface(points, nil, len(points)-1, len(points[0])-1, nil, nil)
+}
+
+func (surf *NURBSSurface) Eval(r, s float64) Vector3 {
+	nr := len(surf.Points)
+	ur := surf.KnotsR[surf.DegreeR] + r*(surf.KnotsR[nr]-surf.KnotsR[surf.DegreeR])
+	ns := len(surf.Points[0])
+	us := surf.KnotsS[surf.DegreeS] + s*(surf.KnotsS[ns]-surf.KnotsS[surf.DegreeS])
+	col := make([]homog, nr)
+	for i := 0; i < nr; i++ {
+		col[i] = deBoor(surf.DegreeS, surf.KnotsS, homogRow(surf.Points[i], surf.Weights[i]), us)
+	}
+	return deBoor(surf.DegreeR, surf.KnotsR, col, ur).point()
+}
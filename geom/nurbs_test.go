@@ -0,0 +1,130 @@
+// nurbs_test.go
+// Check the B-spline and NURBS curves and surfaces on conic sections.
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+func quarterCircle(t *testing.T) *NURBS {
+	t.Helper()
+	s := math.Sqrt(0.5)
+	c, err := NewNURBS([]Vector3{{1.0, 0.0, 0.0}, {1.0, 1.0, 0.0}, {0.0, 1.0, 0.0}},
+		[]float64{1.0, s, 1.0}, 2, nil)
+	if err != nil {
+		t.Fatalf("NewNURBS failed, err: %s", err)
+	}
+	return c
+}
+
+func TestNURBSCurves(t *testing.T) {
+	c := quarterCircle(t)
+	for i := 0; i <= 10; i++ {
+		p := c.Eval(float64(i) / 10.0)
+		if math.Abs(p.Norm()-1.0) > 1.0e-12 {
+			t.Errorf("Quarter circle not on the unit circle at %v: %v", float64(i)/10.0, p)
+		}
+		// The tangent is perpendicular to the radius.
+		if math.Abs(c.Deriv(float64(i)/10.0).Dot(p)) > 1.0e-12 {
+			t.Errorf("Quarter circle tangent error at %v", float64(i)/10.0)
+		}
+	}
+	evalOnly := struct{ Path }{c}
+	if !c.Deriv(0.3).ApproxEquals(PathDeriv(evalOnly, 0.3), 1.0e-6) {
+		t.Errorf("NURBS Deriv error got= %v want= %v", c.Deriv(0.3), PathDeriv(evalOnly, 0.3))
+	}
+	if math.Abs(PathLength(c)-math.Pi/2) > 1.0e-9 {
+		t.Errorf("Quarter circle length error got= %v", PathLength(c))
+	}
+
+	// Full circle from four quarters, with doubled interior knots.
+	s := math.Sqrt(0.5)
+	pts := []Vector3{{1, 0, 0}, {1, 1, 0}, {0, 1, 0}, {-1, 1, 0}, {-1, 0, 0}, {-1, -1, 0}, {0, -1, 0}, {1, -1, 0}, {1, 0, 0}}
+	w := []float64{1, s, 1, s, 1, s, 1, s, 1}
+	knots := []float64{0, 0, 0, 1, 1, 2, 2, 3, 3, 4, 4, 4}
+	circle, err := NewNURBS(pts, w, 2, knots)
+	if err != nil {
+		t.Fatalf("Full circle failed, err: %s", err)
+	}
+	if !circle.Eval(0.625).ApproxEquals(Vector3{-s, -s, 0.0}, 1.0e-12) || math.Abs(PathLength(circle)-2*math.Pi) > 1.0e-9 {
+		t.Errorf("Full circle error p(0.625)= %v length= %v", circle.Eval(0.625), PathLength(circle))
+	}
+
+	// A clamped B-spline with a single span is the Bezier curve.
+	cp := []Vector3{{0, 0, 0}, {1, 2, 0}, {2, -1, 1}, {4, 0, 0}}
+	bs, _ := NewBSpline(cp, 3, nil)
+	bz, _ := NewBezier(cp)
+	for _, tt := range []float64{0.0, 0.2, 0.7, 1.0} {
+		if !bs.Eval(tt).ApproxEquals(bz.Eval(tt), 1.0e-12) || !bs.Deriv(tt).ApproxEquals(bz.Deriv(tt), 1.0e-12) {
+			t.Errorf("B-spline and Bezier differ at %v: %v %v", tt, bs.Eval(tt), bz.Eval(tt))
+		}
+	}
+	// Linear B-spline interpolates its control polygon.
+	lin, _ := NewBSpline(cp, 1, nil)
+	if !lin.Eval(0.5).ApproxEquals(Vector3{1.5, 0.5, 0.5}, 1.0e-12) {
+		t.Errorf("Linear B-spline error got= %v", lin.Eval(0.5))
+	}
+	// Edits to the exported fields take effect at the next evaluation.
+	lin.Points[2] = Vector3{2, 1, 1}
+	if !lin.Eval(0.5).ApproxEquals(Vector3{1.5, 1.5, 0.5}, 1.0e-12) {
+		t.Errorf("Edited control point not used, got= %v", lin.Eval(0.5))
+	}
+	lin.Weights[2] = 3.0
+	if !lin.Eval(0.5).ApproxEquals(Vector3{1.75, 1.25, 0.75}, 1.0e-12) {
+		t.Errorf("Edited weight not used, got= %v", lin.Eval(0.5))
+	}
+
+	_, err = NewBSpline(cp, 4, nil)
+	if err == nil {
+		t.Errorf("Did not detect too few points for the degree.")
+	}
+	_, err = NewBSpline(cp, 2, []float64{0, 0, 0, 1, 0.5, 1, 1})
+	if err == nil {
+		t.Errorf("Did not detect decreasing knots.")
+	}
+	_, err = NewNURBS(cp, []float64{1, 1, -1, 1}, 2, nil)
+	if err == nil {
+		t.Errorf("Did not detect negative weight.")
+	}
+}
+
+func TestNURBSSurface(t *testing.T) {
+	// Quarter of a cylinder of radius 1, extruded along z.
+	s := math.Sqrt(0.5)
+	pts := [][]Vector3{
+		{{1, 0, 0}, {1, 0, 2}},
+		{{1, 1, 0}, {1, 1, 2}},
+		{{0, 1, 0}, {0, 1, 2}},
+	}
+	w := [][]float64{{1, 1}, {s, s}, {1, 1}}
+	cyl, err := NewNURBSSurface(pts, w, 2, 1, nil, nil)
+	if err != nil {
+		t.Fatalf("NewNURBSSurface failed, err: %s", err)
+	}
+	p := cyl.Eval(0.3, 0.6)
+	if math.Abs(math.Hypot(p.X, p.Y)-1.0) > 1.0e-12 || math.Abs(p.Z-1.2) > 1.0e-12 {
+		t.Errorf("Cylinder surface error p= %v", p)
+	}
+	// The outward normal, for this ordering of the net.
+	n := SurfaceNormal(cyl, 0.3, 0.6)
+	if !n.ApproxEquals(Vector3{p.X, p.Y, 0.0}, 1.0e-6) {
+		t.Errorf("Cylinder normal error n= %v", n)
+	}
+
+	// A bilinear Bezier patch.
+	bp, err := NewBezierPatch([][]Vector3{{{0, 0, 0}, {0, 1, 0}}, {{2, 0, 0}, {2, 1, 1}}})
+	if err != nil || !bp.Eval(0.5, 0.5).ApproxEquals(Vector3{1.0, 0.5, 0.25}, 1.0e-12) {
+		t.Errorf("Bezier patch error err= %v", err)
+	}
+	bp.Points[1][1] = Vector3{2, 1, 3}
+	if !bp.Eval(0.5, 0.5).ApproxEquals(Vector3{1.0, 0.5, 0.75}, 1.0e-12) {
+		t.Errorf("Edited patch control point not used, got= %v", bp.Eval(0.5, 0.5))
+	}
+	_, err = NewNURBSSurface([][]Vector3{{{0, 0, 0}, {0, 1, 0}}, {{1, 0, 0}}}, nil, 1, 1, nil, nil)
+	if err == nil {
+		t.Errorf("Did not detect ragged control net.")
+	}
+}
@@ -0,0 +1,191 @@
+// path.go
+// Parametric paths, p(t) for 0 <= t <= 1, as used to describe the
+// boundaries of flow domains such as nozzle contours.
+//
+// A Path need only be evaluated. Those that can also provide an analytic
+// derivative implement DifferentiablePath, and PathDeriv uses that when
+// available and finite differences otherwise.
+//
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+type Path interface {
+	Eval(t float64) Vector3
+}
+
+type DifferentiablePath interface {
+	Path
+	Deriv(t float64) Vector3
+}
+
+// Step for the finite-difference derivatives, in the parameter.
+const derivStep = 1.0e-6
+
+// dp/dt at t, using one-sided differences at the ends of [0, 1].
+func PathDeriv(p Path, t float64) Vector3 {
+	if dp, ok := p.(DifferentiablePath); ok {
+		return dp.Deriv(t)
+	}
+	t0 := math.Max(0.0, t-derivStep)
+	t1 := math.Min(1.0, t+derivStep)
+	return p.Eval(t1).Sub(p.Eval(t0)).Div(t1 - t0)
+}
+
+// Five-point Gauss-Legendre nodes and weights on [-1, 1].
+var (
+	gaussNodes   = [5]float64{-0.9061798459386640, -0.5384693101056831, 0.0, 0.5384693101056831, 0.9061798459386640}
+	gaussWeights = [5]float64{0.2369268850561891, 0.4786286704993665, 0.5688888888888889, 0.4786286704993665, 0.2369268850561891}
+)
+
+// Length of the path between parameter values ta and tb.
+func segmentLength(p Path, ta, tb float64) float64 {
+	half := 0.5 * (tb - ta)
+	mid := 0.5 * (ta + tb)
+	sum := 0.0
+	for k := 0; k < 5; k++ {
+		sum += gaussWeights[k] * PathDeriv(p, mid+half*gaussNodes[k]).Norm()
+	}
+	return half * sum
+}
+
+// Arc length of the whole path.
+func PathLength(p Path) float64 {
+	n := 64
+	length := 0.0
+	for i := 0; i < n; i++ {
+		length += segmentLength(p, float64(i)/float64(n), float64(i+1)/float64(n))
+	}
+	return length
+}
+
+//-----------------------------------------------------------------------------
+// Line
+
+type Line struct {
+	P0, P1 Vector3
+}
+
+func (l Line) Eval(t float64) Vector3 {
+	return l.P0.Lerp(l.P1, t)
+}
+
+func (l Line) Deriv(t float64) Vector3 {
+	return l.P1.Sub(l.P0)
+}
+
+//-----------------------------------------------------------------------------
+// Bezier
+
+// Bezier curve of degree len(Points)-1, passing through the first and
+// last points and tangent there to the control polygon.
+type Bezier struct {
+	Points []Vector3
+}
+
+func NewBezier(points []Vector3) (*Bezier, error) {
+	if len(points) == 0 {
+		return nil, errors.New("Bezier curve needs at least one point")
+	}
+	return &Bezier{Points: append([]Vector3(nil), points...)}, nil
+}
+
+// de Casteljau's algorithm.
+func deCasteljau(points []Vector3, t float64) Vector3 {
+	work := append([]Vector3(nil), points...)
+	for n := len(work) - 1; n > 0; n-- {
+		for i := 0; i < n; i++ {
+			work[i] = work[i].Lerp(work[i+1], t)
+		}
+	}
+	return work[0]
+}
+
+func (b *Bezier) Eval(t float64) Vector3 {
+	return deCasteljau(b.Points, t)
+}
+
+// From the hodograph, the Bezier curve of degree n-1 with
+// control points n.(P[i+1] - P[i]).
+func (b *Bezier) Deriv(t float64) Vector3 {
+	n := len(b.Points) - 1
+	if n == 0 {
+		return Vector3{}
+	}
+	hodo := make([]Vector3, n)
+	for i := 0; i < n; i++ {
+		hodo[i] = b.Points[i+1].Sub(b.Points[i]).Mul(float64(n))
+	}
+	return deCasteljau(hodo, t)
+}
+
+//-----------------------------------------------------------------------------
+// Arc-length reparameterisation
+
+// The underlying path, reparameterised so that the parameter is
+// the fraction of the arc length along the path.
+type ArcLengthPath struct {
+	Underlying Path
+	Length     float64
+	tTable     []float64
+	sTable     []float64
+}
+
+// The arc length is tabulated at n+1 evenly-spaced values of the
+// underlying parameter and the table is refined by Newton iteration
+// on each evaluation, so modest n, such as 20, gives full accuracy
+// for smooth paths.
+func NewArcLengthPath(p Path, n int) (*ArcLengthPath, error) {
+	if n < 1 {
+		return nil, errors.New(fmt.Sprintf("Need at least one interval, n= %d", n))
+	}
+	a := ArcLengthPath{Underlying: p, tTable: make([]float64, n+1), sTable: make([]float64, n+1)}
+	for i := 1; i <= n; i++ {
+		a.tTable[i] = float64(i) / float64(n)
+		a.sTable[i] = a.sTable[i-1] + segmentLength(p, a.tTable[i-1], a.tTable[i])
+	}
+	a.Length = a.sTable[n]
+	if a.Length == 0.0 {
+		return nil, errors.New("Path has zero length")
+	}
+	return &a, nil
+}
+
+// Underlying parameter at the fraction s of the arc length.
+func (a *ArcLengthPath) underlyingT(s float64) float64 {
+	target := s * a.Length
+	n := len(a.sTable) - 1
+	k := sort.SearchFloat64s(a.sTable, target) - 1
+	k = max(0, min(k, n-1))
+	ta, tb := a.tTable[k], a.tTable[k+1]
+	sa, sb := a.sTable[k], a.sTable[k+1]
+	t := ta + (tb-ta)*(target-sa)/(sb-sa)
+	for iter := 0; iter < 5; iter++ {
+		speed := PathDeriv(a.Underlying, t).Norm()
+		if speed == 0.0 {
+			break
+		}
+		f := sa + segmentLength(a.Underlying, ta, t) - target
+		t = math.Max(ta, math.Min(tb, t-f/speed))
+		if math.Abs(f) <= 1.0e-14*a.Length {
+			break
+		}
+	}
+	return t
+}
+
+func (a *ArcLengthPath) Eval(s float64) Vector3 {
+	return a.Underlying.Eval(a.underlyingT(s))
+}
+
+// The derivative has a constant magnitude, the length of the path.
+func (a *ArcLengthPath) Deriv(s float64) Vector3 {
+	return PathDeriv(a.Underlying, a.underlyingT(s)).Unit().Mul(a.Length)
+}
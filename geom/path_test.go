@@ -0,0 +1,76 @@
+// path_test.go
+// Try out the lines, Bezier curves and arc-length reparameterisation.
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLineAndBezier(t *testing.T) {
+	l := Line{Vector3{1.0, 0.0, 0.0}, Vector3{4.0, 4.0, 0.0}}
+	if !l.Eval(0.5).ApproxEquals(Vector3{2.5, 2.0, 0.0}, 1.0e-12) || math.Abs(PathLength(l)-5.0) > 1.0e-12 {
+		t.Errorf("Line error p(0.5)= %v length= %v", l.Eval(0.5), PathLength(l))
+	}
+	// Quadratic Bezier, p(t) = (1-t)^2.P0 + 2t(1-t).P1 + t^2.P2
+	p0, p1, p2 := Vector3{0.0, 0.0, 0.0}, Vector3{1.0, 2.0, 0.0}, Vector3{3.0, 0.0, 1.0}
+	b, err := NewBezier([]Vector3{p0, p1, p2})
+	if err != nil {
+		t.Fatalf("NewBezier failed, err: %s", err)
+	}
+	tt := 0.3
+	want := p0.Mul((1 - tt) * (1 - tt)).Add(p1.Mul(2 * tt * (1 - tt))).Add(p2.Mul(tt * tt))
+	if !b.Eval(tt).ApproxEquals(want, 1.0e-12) {
+		t.Errorf("Bezier Eval error got= %v want= %v", b.Eval(tt), want)
+	}
+	dwant := p1.Sub(p0).Mul(2 * (1 - tt)).Add(p2.Sub(p1).Mul(2 * tt))
+	if !b.Deriv(tt).ApproxEquals(dwant, 1.0e-12) {
+		t.Errorf("Bezier Deriv error got= %v want= %v", b.Deriv(tt), dwant)
+	}
+	// The finite-difference fallback, for a Path without Deriv.
+	evalOnly := struct{ Path }{b}
+	if !PathDeriv(evalOnly, tt).ApproxEquals(dwant, 1.0e-6) || !PathDeriv(evalOnly, 1.0).ApproxEquals(b.Deriv(1.0), 1.0e-5) {
+		t.Errorf("PathDeriv finite-difference error got= %v want= %v", PathDeriv(evalOnly, tt), dwant)
+	}
+	_, err = NewBezier(nil)
+	if err == nil {
+		t.Errorf("Did not detect empty Bezier.")
+	}
+}
+
+func TestArcLengthPath(t *testing.T) {
+	// A cubic Bezier with very uneven parametric speed.
+	b, _ := NewBezier([]Vector3{{0.0, 0.0, 0.0}, {0.1, 0.0, 0.0}, {0.2, 0.0, 0.0}, {3.0, 1.0, 0.0}})
+	a, err := NewArcLengthPath(b, 20)
+	if err != nil {
+		t.Fatalf("NewArcLengthPath failed, err: %s", err)
+	}
+	if math.Abs(a.Length-PathLength(b)) > 1.0e-9 {
+		t.Errorf("Arc length error got= %v want= %v", a.Length, PathLength(b))
+	}
+	if !a.Eval(0.0).ApproxEquals(b.Eval(0.0), 1.0e-12) || !a.Eval(1.0).ApproxEquals(b.Eval(1.0), 1.0e-9) {
+		t.Errorf("Arc-length path end points error")
+	}
+	// Equal steps in s give equal lengths along the curve.
+	n := 10
+	for i := 0; i < n; i++ {
+		s0, s1 := float64(i)/float64(n), float64(i+1)/float64(n)
+		ta, tb := a.underlyingT(s0), a.underlyingT(s1)
+		sub := 0.0
+		for k := 0; k < 50; k++ {
+			sub += segmentLength(b, ta+(tb-ta)*float64(k)/50.0, ta+(tb-ta)*float64(k+1)/50.0)
+		}
+		if math.Abs(sub-a.Length/float64(n)) > 1.0e-9 {
+			t.Errorf("Arc-length step %d error got= %v want= %v", i, sub, a.Length/float64(n))
+		}
+	}
+	if math.Abs(a.Deriv(0.37).Norm()-a.Length) > 1.0e-9 {
+		t.Errorf("Arc-length derivative magnitude error got= %v", a.Deriv(0.37).Norm())
+	}
+	_, err = NewArcLengthPath(Line{}, 10)
+	if err == nil {
+		t.Errorf("Did not detect zero-length path.")
+	}
+}
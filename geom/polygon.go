@@ -0,0 +1,262 @@
+// polygon.go
+// Planar polygons and convex hulls of point sets.
+//
+// Polygons are given as []Vector3 vertex lists, without repeating the first
+// vertex at the end. The planar operations work in the xy-plane and ignore
+// the z components; PolygonVectorArea handles a polygon in any plane.
+// Decisions about which side of a line or plane a point lies on are made
+// with the exact predicates Orient2D and Orient3D. These treat points
+// with non-finite coordinates as degenerate, so such points give results
+// of no particular meaning, but do not cause a panic.
+//
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Signed area in the xy-plane, positive for counter-clockwise vertices.
+func PolygonArea(poly []Vector3) float64 {
+	n := len(poly)
+	area := 0.0
+	for i := 0; i < n; i++ {
+		p, q := poly[i], poly[(i+1)%n]
+		area += p.X*q.Y - q.X*p.Y
+	}
+	return 0.5 * area
+}
+
+// Centroid of the area in the xy-plane, with z being the average of the vertices.
+func PolygonCentroid(poly []Vector3) (Vector3, error) {
+	n := len(poly)
+	area := PolygonArea(poly)
+	if area == 0.0 {
+		return Vector3{}, errors.New("Polygon has zero area")
+	}
+	var cx, cy, z float64
+	for i := 0; i < n; i++ {
+		p, q := poly[i], poly[(i+1)%n]
+		cross := p.X*q.Y - q.X*p.Y
+		cx += (p.X + q.X) * cross
+		cy += (p.Y + q.Y) * cross
+		z += p.Z
+	}
+	return Vector3{cx / (6.0 * area), cy / (6.0 * area), z / float64(n)}, nil
+}
+
+// Vector area of a polygon in 3-D, by Newell's method, with magnitude equal
+// to the area of a planar polygon and direction along its right-hand normal.
+func PolygonVectorArea(poly []Vector3) Vector3 {
+	var va Vector3
+	n := len(poly)
+	for i := 0; i < n; i++ {
+		va = va.Add(poly[i].Cross(poly[(i+1)%n]))
+	}
+	return va.Mul(0.5)
+}
+
+func onSegment2D(p, a, b Vector3) bool {
+	return Orient2D(a, b, p) == 0 &&
+		p.X >= min(a.X, b.X) && p.X <= max(a.X, b.X) &&
+		p.Y >= min(a.Y, b.Y) && p.Y <= max(a.Y, b.Y)
+}
+
+// True if p is inside the polygon or on its boundary, in the xy-plane.
+// The winding-number test is used, so that self-overlapping parts count
+// as inside and the vertex order does not matter.
+func PointInPolygon(p Vector3, poly []Vector3) bool {
+	n := len(poly)
+	winding := 0
+	for i := 0; i < n; i++ {
+		a, b := poly[i], poly[(i+1)%n]
+		if onSegment2D(p, a, b) {
+			return true
+		}
+		if a.Y <= p.Y {
+			if b.Y > p.Y && Orient2D(a, b, p) > 0 {
+				winding++
+			}
+		} else if b.Y <= p.Y && Orient2D(a, b, p) < 0 {
+			winding--
+		}
+	}
+	return winding != 0
+}
+
+// Part of the subject polygon within the convex clip polygon, in the xy-plane,
+// by the algorithm of Sutherland and Hodgman (1974). The clip polygon must have
+// counter-clockwise vertices. A concave subject may give a result with
+// zero-width connecting edges along the clip boundary.
+// The result is empty if the polygons do not overlap.
+func ClipPolygon(subject, clip []Vector3) []Vector3 {
+	out := append([]Vector3(nil), subject...)
+	m := len(clip)
+	for j := 0; j < m && len(out) > 0; j++ {
+		a, b := clip[j], clip[(j+1)%m]
+		in := out
+		out = nil
+		n := len(in)
+		for i := 0; i < n; i++ {
+			p, q := in[i], in[(i+1)%n]
+			pIn := Orient2D(a, b, p) >= 0
+			qIn := Orient2D(a, b, q) >= 0
+			if pIn {
+				out = append(out, p)
+			}
+			if pIn != qIn {
+				out = append(out, lineIntersect2D(p, q, a, b))
+			}
+		}
+	}
+	return out
+}
+
+// Point where segment pq crosses the line through a and b,
+// with z interpolated along pq. The caller ensures that they do cross.
+func lineIntersect2D(p, q, a, b Vector3) Vector3 {
+	// Signed distances, scaled by |b-a|, of p and q from the line.
+	dp := (b.X-a.X)*(p.Y-a.Y) - (b.Y-a.Y)*(p.X-a.X)
+	dq := (b.X-a.X)*(q.Y-a.Y) - (b.Y-a.Y)*(q.X-a.X)
+	t := dp / (dp - dq)
+	return p.Lerp(q, clamp01(t))
+}
+
+//-----------------------------------------------------------------------------
+// Convex hulls
+
+// Convex hull in the xy-plane by Andrew's monotone chain algorithm, with
+// vertices counter-clockwise from the lowest-leftmost point. Points on the
+// edges of the hull are not included. Fewer than three distinct points, or
+// collinear points, give a degenerate hull of one or two points.
+func ConvexHull2D(points []Vector3) []Vector3 {
+	pts := append([]Vector3(nil), points...)
+	sort.Slice(pts, func(i, j int) bool {
+		if pts[i].X != pts[j].X {
+			return pts[i].X < pts[j].X
+		}
+		return pts[i].Y < pts[j].Y
+	})
+	if len(pts) < 3 {
+		return pts
+	}
+	hull := make([]Vector3, 0, 2*len(pts))
+	// Lower hull, then upper hull.
+	for _, p := range pts {
+		for len(hull) >= 2 && Orient2D(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	lower := len(hull) + 1
+	for i := len(pts) - 2; i >= 0; i-- {
+		p := pts[i]
+		for len(hull) >= lower && Orient2D(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	// The last point repeats the first.
+	hull = hull[:len(hull)-1]
+	if len(hull) == 2 && hull[0] == hull[1] {
+		hull = hull[:1]
+	}
+	return hull
+}
+
+// Convex hull in 3-D by the incremental algorithm, as triangles of indices
+// into points, ordered counter-clockwise when seen from outside, so that the
+// right-hand normals point outward. Points inside the hull are not included
+// as vertices, nor are points on its faces that come after the vertices of
+// those faces; otherwise, a boundary point may give extra coplanar triangles.
+// An error is returned if all points are coplanar.
+func ConvexHull3D(points []Vector3) ([][3]int, error) {
+	n := len(points)
+	// An initial tetrahedron from the first non-degenerate points.
+	i1 := -1
+	for i := 1; i < n; i++ {
+		if points[i] != points[0] {
+			i1 = i
+			break
+		}
+	}
+	i2 := -1
+	for i := i1 + 1; i1 > 0 && i < n; i++ {
+		if !collinear3D(points[0], points[i1], points[i]) {
+			i2 = i
+			break
+		}
+	}
+	i3 := -1
+	for i := i2 + 1; i2 > 0 && i < n; i++ {
+		if Orient3D(points[0], points[i1], points[i2], points[i]) != 0 {
+			i3 = i
+			break
+		}
+	}
+	if i3 < 0 {
+		return nil, errors.New(fmt.Sprintf("All %d points are coplanar", n))
+	}
+	tet := [4]int{0, i1, i2, i3}
+	if Orient3D(points[0], points[i1], points[i2], points[i3]) > 0 {
+		// Make the base face outward, away from the fourth point.
+		tet[1], tet[2] = tet[2], tet[1]
+	}
+	faces := [][3]int{
+		{tet[0], tet[1], tet[2]},
+		{tet[0], tet[3], tet[1]},
+		{tet[1], tet[3], tet[2]},
+		{tet[2], tet[3], tet[0]},
+	}
+	for p := 0; p < n; p++ {
+		if p == tet[0] || p == tet[1] || p == tet[2] || p == tet[3] {
+			continue
+		}
+		// Faces that p can see, strictly, from outside.
+		visibleEdges := map[[2]int]bool{}
+		var kept [][3]int
+		for _, f := range faces {
+			if Orient3D(points[f[0]], points[f[1]], points[f[2]], points[p]) > 0 {
+				for k := 0; k < 3; k++ {
+					visibleEdges[[2]int{f[k], f[(k+1)%3]}] = true
+				}
+			} else {
+				kept = append(kept, f)
+			}
+		}
+		if len(visibleEdges) == 0 {
+			continue
+		}
+		// The horizon consists of the visible edges whose reverse is not visible.
+		// Go through them in a fixed order, for reproducible output.
+		var horizon [][2]int
+		for e := range visibleEdges {
+			if !visibleEdges[[2]int{e[1], e[0]}] {
+				horizon = append(horizon, e)
+			}
+		}
+		sort.Slice(horizon, func(i, j int) bool {
+			if horizon[i][0] != horizon[j][0] {
+				return horizon[i][0] < horizon[j][0]
+			}
+			return horizon[i][1] < horizon[j][1]
+		})
+		for _, e := range horizon {
+			kept = append(kept, [3]int{e[0], e[1], p})
+		}
+		faces = kept
+	}
+	return faces, nil
+}
+
+// Exact test for three points on a line, from their projections
+// onto the coordinate planes.
+func collinear3D(a, b, c Vector3) bool {
+	yz := func(v Vector3) Vector3 { return Vector3{v.Y, v.Z, 0.0} }
+	zx := func(v Vector3) Vector3 { return Vector3{v.Z, v.X, 0.0} }
+	return Orient2D(a, b, c) == 0 && Orient2D(yz(a), yz(b), yz(c)) == 0 &&
+		Orient2D(zx(a), zx(b), zx(c)) == 0
+}
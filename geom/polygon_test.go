@@ -0,0 +1,182 @@
+// polygon_test.go
+// Try out the polygon operations and convex hulls.
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPolygon(t *testing.T) {
+	// An L-shape made from a 2x1 and a 1x1 square, counter-clockwise.
+	ell := []Vector3{{0.0, 0.0, 0.0}, {2.0, 0.0, 0.0}, {2.0, 1.0, 0.0}, {1.0, 1.0, 0.0}, {1.0, 2.0, 0.0}, {0.0, 2.0, 0.0}}
+	if PolygonArea(ell) != 3.0 {
+		t.Errorf("PolygonArea error got= %v want= 3", PolygonArea(ell))
+	}
+	cw := []Vector3{ell[5], ell[4], ell[3], ell[2], ell[1], ell[0]}
+	if PolygonArea(cw) != -3.0 {
+		t.Errorf("Clockwise PolygonArea error got= %v", PolygonArea(cw))
+	}
+	c, err := PolygonCentroid(ell)
+	want := Vector3{5.0 / 6.0, 5.0 / 6.0, 0.0}
+	if err != nil || !c.ApproxEquals(want, 1.0e-12) {
+		t.Errorf("PolygonCentroid error got= %v want= %v", c, want)
+	}
+	_, err = PolygonCentroid([]Vector3{{0.0, 0.0, 0.0}, {1.0, 1.0, 0.0}, {2.0, 2.0, 0.0}})
+	if err == nil {
+		t.Errorf("Did not detect zero-area polygon.")
+	}
+	// The same L-shape in a tilted plane keeps its area.
+	rot := RotationX(0.4).Mul(RotationZ(1.1))
+	var tilted []Vector3
+	for _, p := range ell {
+		tilted = append(tilted, rot.MulVec(p))
+	}
+	va := PolygonVectorArea(tilted)
+	if math.Abs(va.Norm()-3.0) > 1.0e-12 || !va.Unit().ApproxEquals(rot.MulVec(Vector3{0.0, 0.0, 1.0}), 1.0e-12) {
+		t.Errorf("PolygonVectorArea error got= %v", va)
+	}
+
+	for _, tc := range []struct {
+		p    Vector3
+		want bool
+	}{
+		{Vector3{0.5, 1.5, 0.0}, true},
+		{Vector3{1.5, 0.5, 0.0}, true},
+		{Vector3{1.5, 1.5, 0.0}, false},
+		{Vector3{1.0, 1.5, 0.0}, true},   // on an edge
+		{Vector3{1.0, 1.0, 0.0}, true},   // at the reflex vertex
+		{Vector3{-0.5, 1.0, 0.0}, false}, // level with a vertex
+		{Vector3{2.5, 1.0, 0.0}, false},
+	} {
+		if PointInPolygon(tc.p, ell) != tc.want || PointInPolygon(tc.p, cw) != tc.want {
+			t.Errorf("PointInPolygon error at %v want= %v", tc.p, tc.want)
+		}
+	}
+
+	square := func(x0, y0, size float64) []Vector3 {
+		return []Vector3{{x0, y0, 0.0}, {x0 + size, y0, 0.0}, {x0 + size, y0 + size, 0.0}, {x0, y0 + size, 0.0}}
+	}
+	clipped := ClipPolygon(square(0.0, 0.0, 2.0), square(1.0, 1.0, 2.0))
+	if len(clipped) != 4 || PolygonArea(clipped) != 1.0 {
+		t.Errorf("ClipPolygon error got= %v", clipped)
+	}
+	clipped = ClipPolygon(ell, square(0.5, 0.5, 1.0))
+	if math.Abs(PolygonArea(clipped)-0.75) > 1.0e-12 {
+		t.Errorf("ClipPolygon of L-shape error got area= %v", PolygonArea(clipped))
+	}
+	if len(ClipPolygon(square(0.0, 0.0, 1.0), square(5.0, 5.0, 1.0))) != 0 {
+		t.Errorf("ClipPolygon of separate squares should be empty")
+	}
+	// NaN coordinates give no meaningful result, but must not panic.
+	nan := Vector3{math.NaN(), 0.5, 0.0}
+	PointInPolygon(nan, ell)
+	PointInPolygon(Vector3{0.5, 0.5, 0.0}, append([]Vector3{nan}, ell...))
+	ClipPolygon(append([]Vector3{nan}, ell...), square(0.5, 0.5, 1.0))
+}
+
+func TestConvexHull2D(t *testing.T) {
+	points := []Vector3{
+		{0.5, 0.5, 0.0}, {1.0, 1.0, 0.0}, {0.0, 0.0, 0.0}, {1.0, 0.0, 0.0},
+		{0.5, 0.0, 0.0}, {0.0, 1.0, 0.0}, {0.2, 0.7, 0.0}, {1.0, 0.5, 0.0},
+	}
+	hull := ConvexHull2D(points)
+	want := []Vector3{{0.0, 0.0, 0.0}, {1.0, 0.0, 0.0}, {1.0, 1.0, 0.0}, {0.0, 1.0, 0.0}}
+	if len(hull) != len(want) {
+		t.Fatalf("ConvexHull2D error got= %v want= %v", hull, want)
+	}
+	for i := range want {
+		if hull[i] != want[i] {
+			t.Errorf("ConvexHull2D error got= %v want= %v", hull, want)
+			break
+		}
+	}
+	line := ConvexHull2D([]Vector3{{2.0, 2.0, 0.0}, {0.0, 0.0, 0.0}, {1.0, 1.0, 0.0}})
+	if len(line) != 2 || line[0] != (Vector3{0.0, 0.0, 0.0}) || line[1] != (Vector3{2.0, 2.0, 0.0}) {
+		t.Errorf("ConvexHull2D of collinear points error got= %v", line)
+	}
+}
+
+func TestConvexHull3D(t *testing.T) {
+	// The cube corners, then points inside and on its faces and edges.
+	points := append([]Vector3(nil), unitCube[:]...)
+	points = append(points, Vector3{0.5, 0.5, 0.5}, Vector3{0.5, 0.5, 0.0}, Vector3{0.2, 0.9, 0.3},
+		Vector3{0.5, 1.0, 0.5}, Vector3{1.0, 0.5, 0.5}, Vector3{0.0, 0.0, 0.5})
+	faces, err := ConvexHull3D(points)
+	if err != nil {
+		t.Fatalf("ConvexHull3D failed, err: %s", err)
+	}
+	vertices := checkHull(t, points, faces)
+	if len(vertices) != 8 {
+		t.Errorf("ConvexHull3D error, %d vertices", len(vertices))
+	}
+	for v := range vertices {
+		if v >= 8 {
+			t.Errorf("Hull vertex %v is not a cube corner", points[v])
+		}
+	}
+	volume := 0.0
+	for _, f := range faces {
+		volume += TetVolume(points[8], points[f[0]], points[f[1]], points[f[2]])
+	}
+	if math.Abs(volume-1.0) > 1.0e-12 {
+		t.Errorf("ConvexHull3D volume error got= %v want= 1", volume)
+	}
+
+	// Points scattered in a ball.
+	rng := rand.New(rand.NewSource(42))
+	points = nil
+	for len(points) < 200 {
+		p := Vector3{2.0*rng.Float64() - 1.0, 2.0*rng.Float64() - 1.0, 2.0*rng.Float64() - 1.0}
+		if p.Norm() <= 1.0 {
+			points = append(points, p)
+		}
+	}
+	faces, err = ConvexHull3D(points)
+	if err != nil {
+		t.Fatalf("ConvexHull3D failed, err: %s", err)
+	}
+	checkHull(t, points, faces)
+
+	// A NaN point is never seen as outside a face, so it is left out.
+	faces, err = ConvexHull3D(append(append([]Vector3(nil), unitCube[:]...), Vector3{math.NaN(), 0.5, 0.5}))
+	if err != nil || len(checkHull(t, unitCube[:], faces)) != 8 {
+		t.Errorf("ConvexHull3D with a NaN point error err= %v", err)
+	}
+	ConvexHull2D([]Vector3{{0.0, 0.0, 0.0}, {1.0, 0.0, 0.0}, {math.NaN(), 1.0, 0.0}, {0.0, 1.0, 0.0}})
+
+	_, err = ConvexHull3D([]Vector3{{0.0, 0.0, 1.0}, {1.0, 0.0, 1.0}, {0.0, 1.0, 1.0}, {0.3, 0.3, 1.0}})
+	if err == nil {
+		t.Errorf("Did not detect coplanar points.")
+	}
+}
+
+// Checks that every face is outward, with no point beyond it, and that
+// the faces close the surface, with V - E + F = 2. Returns the vertices.
+func checkHull(t *testing.T, points []Vector3, faces [][3]int) map[int]bool {
+	edges := map[[2]int]int{}
+	vertices := map[int]bool{}
+	for _, f := range faces {
+		for k := 0; k < 3; k++ {
+			vertices[f[k]] = true
+			edges[[2]int{f[k], f[(k+1)%3]}]++
+		}
+		for _, p := range points {
+			if Orient3D(points[f[0]], points[f[1]], points[f[2]], p) > 0 {
+				t.Fatalf("Face %v is not outward from point %v", f, p)
+			}
+		}
+	}
+	for e, count := range edges {
+		if count != 1 || edges[[2]int{e[1], e[0]}] != 1 {
+			t.Errorf("Hull edge %v is not shared by exactly two faces", e)
+		}
+	}
+	if len(vertices)-len(edges)/2+len(faces) != 2 {
+		t.Errorf("Hull topology error V= %d E= %d F= %d", len(vertices), len(edges)/2, len(faces))
+	}
+	return vertices
+}
@@ -0,0 +1,117 @@
+// predicates.go
+// Robust orientation predicates.
+//
+// The determinants are first evaluated in floating point and the sign is
+// accepted if the result exceeds a bound on its rounding error, as per
+// J.R. Shewchuk (1997) Adaptive precision floating-point arithmetic and fast
+// robust geometric predicates. Discrete & Computational Geometry 18:305-363.
+// Otherwise, the determinant is evaluated exactly with big.Rat, which is
+// slow but needed only for (nearly) degenerate configurations.
+// Points with NaN or infinite coordinates have no defined orientation,
+// and the predicates return 0 for them, as for degenerate points.
+//
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"math"
+	"math/big"
+)
+
+// Error-bound coefficients of Shewchuk's orient2d and orient3d filters.
+const (
+	epsilonHalf      = 1.1102230246251565e-16 // 2^-53
+	orient2dErrBound = (3.0 + 16.0*epsilonHalf) * epsilonHalf
+	orient3dErrBound = (7.0 + 56.0*epsilonHalf) * epsilonHalf
+)
+
+func sign(x float64) int {
+	switch {
+	case x > 0.0:
+		return 1
+	case x < 0.0:
+		return -1
+	}
+	return 0
+}
+
+// +1 if a, b, c are in counter-clockwise order in the xy-plane,
+// -1 if clockwise and 0 if they are collinear. The z components are ignored.
+func Orient2D(a, b, c Vector3) int {
+	detLeft := (a.X - c.X) * (b.Y - c.Y)
+	detRight := (a.Y - c.Y) * (b.X - c.X)
+	det := detLeft - detRight
+	if math.Abs(det) > orient2dErrBound*(math.Abs(detLeft)+math.Abs(detRight)) {
+		return sign(det)
+	}
+	if !isFinite(a, b, c) {
+		return 0
+	}
+	return orient2DExact(a, b, c)
+}
+
+// Non-finite values get through the floating-point filter, since the
+// comparisons with NaN are false, but cannot be converted to big.Rat.
+func isFinite(points ...Vector3) bool {
+	for _, p := range points {
+		for _, x := range [3]float64{p.X, p.Y, p.Z} {
+			if math.IsNaN(x) || math.IsInf(x, 0) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func rat(x float64) *big.Rat {
+	return new(big.Rat).SetFloat64(x)
+}
+
+func ratSub(x, y float64) *big.Rat {
+	return new(big.Rat).Sub(rat(x), rat(y))
+}
+
+func orient2DExact(a, b, c Vector3) int {
+	left := new(big.Rat).Mul(ratSub(a.X, c.X), ratSub(b.Y, c.Y))
+	right := new(big.Rat).Mul(ratSub(a.Y, c.Y), ratSub(b.X, c.X))
+	return left.Sub(left, right).Sign()
+}
+
+// +1 if d lies on the side of the plane through a, b, c toward which
+// (b-a)x(c-a) points, -1 if on the other side and 0 if the four points
+// are coplanar. This is the sign of TetVolume(a, b, c, d).
+func Orient3D(a, b, c, d Vector3) int {
+	adx, ady, adz := a.X-d.X, a.Y-d.Y, a.Z-d.Z
+	bdx, bdy, bdz := b.X-d.X, b.Y-d.Y, b.Z-d.Z
+	cdx, cdy, cdz := c.X-d.X, c.Y-d.Y, c.Z-d.Z
+	bdxcdy, cdxbdy := bdx*cdy, cdx*bdy
+	cdxady, adxcdy := cdx*ady, adx*cdy
+	adxbdy, bdxady := adx*bdy, bdx*ady
+	det := adz*(bdxcdy-cdxbdy) + bdz*(cdxady-adxcdy) + cdz*(adxbdy-bdxady)
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*math.Abs(adz) +
+		(math.Abs(cdxady)+math.Abs(adxcdy))*math.Abs(bdz) +
+		(math.Abs(adxbdy)+math.Abs(bdxady))*math.Abs(cdz)
+	if math.Abs(det) > orient3dErrBound*permanent {
+		// Shewchuk's determinant is positive for d below the plane.
+		return -sign(det)
+	}
+	if !isFinite(a, b, c, d) {
+		return 0
+	}
+	return -orient3DExact(a, b, c, d)
+}
+
+func orient3DExact(a, b, c, d Vector3) int {
+	adx, ady, adz := ratSub(a.X, d.X), ratSub(a.Y, d.Y), ratSub(a.Z, d.Z)
+	bdx, bdy, bdz := ratSub(b.X, d.X), ratSub(b.Y, d.Y), ratSub(b.Z, d.Z)
+	cdx, cdy, cdz := ratSub(c.X, d.X), ratSub(c.Y, d.Y), ratSub(c.Z, d.Z)
+	minor := func(px, py, qx, qy *big.Rat) *big.Rat {
+		m := new(big.Rat).Mul(px, qy)
+		return m.Sub(m, new(big.Rat).Mul(qx, py))
+	}
+	det := new(big.Rat).Mul(adz, minor(bdx, bdy, cdx, cdy))
+	det.Add(det, new(big.Rat).Mul(bdz, minor(cdx, cdy, adx, ady)))
+	det.Add(det, new(big.Rat).Mul(cdz, minor(adx, ady, bdx, bdy)))
+	return det.Sign()
+}
@@ -0,0 +1,92 @@
+// predicates_test.go
+// Try out the robust orientation predicates on nearly degenerate points.
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestOrient2D(t *testing.T) {
+	a, b, c := Vector3{0.0, 0.0, 5.0}, Vector3{1.0, 0.0, -2.0}, Vector3{0.0, 1.0, 0.0}
+	if Orient2D(a, b, c) != 1 || Orient2D(a, c, b) != -1 || Orient2D(a, b, Vector3{3.0, 0.0, 1.0}) != 0 {
+		t.Errorf("Orient2D simple cases error")
+	}
+	// Points near the line y = x, on a grid of neighbouring floating-point
+	// values, as per Kettner et al. (2008) Classroom examples of robustness
+	// problems in geometric computations. The naive determinant gets many
+	// of these wrong, so compare with an exact evaluation done here.
+	b, c = Vector3{12.0, 12.0, 0.0}, Vector3{24.0, 24.0, 0.0}
+	ulp := math.Pow(2.0, -53.0)
+	nPos, nNeg, nZero := 0, 0, 0
+	for i := 0; i < 64; i++ {
+		for j := 0; j < 64; j++ {
+			a = Vector3{0.5 + float64(i)*ulp, 0.5 + float64(j)*ulp, 0.0}
+			want := orientByRat(a, b, c)
+			got := Orient2D(a, b, c)
+			if got != want {
+				t.Fatalf("Orient2D error at i=%d j=%d got= %d want= %d", i, j, got, want)
+			}
+			switch got {
+			case 1:
+				nPos++
+			case -1:
+				nNeg++
+			default:
+				nZero++
+			}
+		}
+	}
+	if nPos == 0 || nNeg == 0 || nZero == 0 {
+		t.Errorf("Orient2D grid should have all three signs, got %d %d %d", nPos, nNeg, nZero)
+	}
+	// Non-finite coordinates give 0 rather than a panic.
+	for _, x := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if Orient2D(Vector3{x, 0.0, 0.0}, b, c) != 0 || Orient2D(a, b, Vector3{1.0, x, 0.0}) != 0 {
+			t.Errorf("Orient2D with non-finite coordinate %v should give 0", x)
+		}
+	}
+}
+
+// Sign of (b-a)x(c-a), evaluated in exact rational arithmetic.
+func orientByRat(a, b, c Vector3) int {
+	r := func(x float64) *big.Rat { return new(big.Rat).SetFloat64(x) }
+	bax := new(big.Rat).Sub(r(b.X), r(a.X))
+	bay := new(big.Rat).Sub(r(b.Y), r(a.Y))
+	cax := new(big.Rat).Sub(r(c.X), r(a.X))
+	cay := new(big.Rat).Sub(r(c.Y), r(a.Y))
+	det := new(big.Rat).Mul(bax, cay)
+	return det.Sub(det, new(big.Rat).Mul(bay, cax)).Sign()
+}
+
+func TestOrient3D(t *testing.T) {
+	a, b, c := Vector3{0.0, 0.0, 0.0}, Vector3{1.0, 0.0, 0.0}, Vector3{0.0, 1.0, 0.0}
+	above, below := Vector3{0.2, 0.3, 1.0}, Vector3{0.2, 0.3, -1.0}
+	if Orient3D(a, b, c, above) != 1 || Orient3D(a, b, c, below) != -1 || Orient3D(a, c, b, above) != -1 {
+		t.Errorf("Orient3D simple cases error")
+	}
+	if Orient3D(a, b, c, above) != int(math.Copysign(1.0, TetVolume(a, b, c, above))) {
+		t.Errorf("Orient3D does not agree with TetVolume")
+	}
+	// Points on the tilted plane x + y + z = 1, with binary fractions
+	// as coordinates so that they lie on it exactly.
+	p1 := Vector3{0.375, 0.125, 0.5}
+	p2 := Vector3{0.8125, 0.0625, 0.125}
+	p3 := Vector3{0.25, 0.25, 0.5}
+	if Orient3D(p1, p2, p3, Vector3{0.5, 0.25, 0.25}) != 0 {
+		t.Errorf("Orient3D exactly coplanar error")
+	}
+	// The smallest step off the plane is still detected.
+	d := Vector3{0.5, 0.25, math.Nextafter(0.25, 1.0)}
+	if Orient3D(p1, p2, p3, d) != 1 {
+		t.Errorf("Orient3D near-coplanar error got= %d", Orient3D(p1, p2, p3, d))
+	}
+	for _, x := range []float64{math.NaN(), math.Inf(1)} {
+		if Orient3D(a, b, c, Vector3{0.2, 0.3, x}) != 0 || Orient3D(Vector3{x, 0.0, 0.0}, b, c, above) != 0 {
+			t.Errorf("Orient3D with non-finite coordinate %v should give 0", x)
+		}
+	}
+}
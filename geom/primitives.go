@@ -0,0 +1,428 @@
+// primitives.go
+// Geometric primitives built on Vector3, with intersection tests,
+// closest-point queries and distances.
+//
+// Intersection tests report a miss for lines that are parallel to
+// a plane or triangle to within a relative tolerance, rather than
+// returning a huge parameter value from a tiny divisor.
+// Many of the algorithms follow C. Ericson (2005) Real-Time Collision
+// Detection, Morgan Kaufmann.
+//
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Relative tolerance for deciding that directions are parallel.
+const parallelTol = 1.0e-12
+
+//-----------------------------------------------------------------------------
+// Ray
+
+// Points Origin + t.Dir for t >= 0.
+type Ray struct {
+	Origin, Dir Vector3
+}
+
+func (r Ray) At(t float64) Vector3 {
+	return r.Origin.Add(r.Dir.Mul(t))
+}
+
+// Closest point on the ray to p, and its parameter value.
+func (r Ray) ClosestPoint(p Vector3) (Vector3, float64) {
+	dd := r.Dir.NormSq()
+	if dd == 0.0 {
+		return r.Origin, 0.0
+	}
+	t := math.Max(0.0, p.Sub(r.Origin).Dot(r.Dir)/dd)
+	return r.At(t), t
+}
+
+func (r Ray) Distance(p Vector3) float64 {
+	c, _ := r.ClosestPoint(p)
+	return c.Distance(p)
+}
+
+// Parameter value at which the ray meets the plane.
+// A ray parallel to the plane, even if it lies within it, reports a miss.
+func (r Ray) IntersectPlane(pl Plane) (float64, bool) {
+	denom := pl.Normal.Dot(r.Dir)
+	if math.Abs(denom) <= parallelTol*r.Dir.Norm() {
+		return 0.0, false
+	}
+	t := -pl.SignedDistance(r.Origin) / denom
+	if t < 0.0 {
+		return 0.0, false
+	}
+	return t, true
+}
+
+// Moller and Trumbore (1997) Fast, minimum storage ray-triangle intersection.
+// Returns the ray parameter and the barycentric coordinates (u, v) of the hit,
+// which is at (1-u-v).A + u.B + v.C. Hits on the edges count.
+func (r Ray) IntersectTriangle(tri Triangle) (t, u, v float64, ok bool) {
+	e1 := tri.B.Sub(tri.A)
+	e2 := tri.C.Sub(tri.A)
+	p := r.Dir.Cross(e2)
+	det := e1.Dot(p)
+	if math.Abs(det) <= parallelTol*e1.Norm()*e2.Norm()*r.Dir.Norm() {
+		return 0.0, 0.0, 0.0, false
+	}
+	inv := 1.0 / det
+	s := r.Origin.Sub(tri.A)
+	u = s.Dot(p) * inv
+	if u < 0.0 || u > 1.0 {
+		return 0.0, 0.0, 0.0, false
+	}
+	q := s.Cross(e1)
+	v = r.Dir.Dot(q) * inv
+	if v < 0.0 || u+v > 1.0 {
+		return 0.0, 0.0, 0.0, false
+	}
+	t = e2.Dot(q) * inv
+	if t < 0.0 {
+		return 0.0, 0.0, 0.0, false
+	}
+	return t, u, v, true
+}
+
+// Slab test, giving the parameter interval [tmin, tmax] of the ray within the box.
+// Direction components that are zero are handled through the infinities.
+func (r Ray) IntersectAABB(box AABB) (tmin, tmax float64, ok bool) {
+	tmin, tmax = 0.0, math.Inf(1)
+	o := [3]float64{r.Origin.X, r.Origin.Y, r.Origin.Z}
+	d := [3]float64{r.Dir.X, r.Dir.Y, r.Dir.Z}
+	lo := [3]float64{box.Min.X, box.Min.Y, box.Min.Z}
+	hi := [3]float64{box.Max.X, box.Max.Y, box.Max.Z}
+	for k := 0; k < 3; k++ {
+		if d[k] == 0.0 {
+			if o[k] < lo[k] || o[k] > hi[k] {
+				return 0.0, 0.0, false
+			}
+			continue
+		}
+		t1 := (lo[k] - o[k]) / d[k]
+		t2 := (hi[k] - o[k]) / d[k]
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		tmin = math.Max(tmin, t1)
+		tmax = math.Min(tmax, t2)
+		if tmin > tmax {
+			return 0.0, 0.0, false
+		}
+	}
+	return tmin, tmax, true
+}
+
+//-----------------------------------------------------------------------------
+// Segment
+
+// Points A + t.(B-A) for 0 <= t <= 1.
+type Segment struct {
+	A, B Vector3
+}
+
+func (s Segment) At(t float64) Vector3 {
+	return s.A.Lerp(s.B, t)
+}
+
+func (s Segment) Length() float64 {
+	return s.A.Distance(s.B)
+}
+
+// Closest point on the segment to p, and its parameter value.
+func (s Segment) ClosestPoint(p Vector3) (Vector3, float64) {
+	d := s.B.Sub(s.A)
+	dd := d.NormSq()
+	if dd == 0.0 {
+		return s.A, 0.0
+	}
+	t := math.Min(1.0, math.Max(0.0, p.Sub(s.A).Dot(d)/dd))
+	return s.At(t), t
+}
+
+func (s Segment) Distance(p Vector3) float64 {
+	c, _ := s.ClosestPoint(p)
+	return c.Distance(p)
+}
+
+// Closest points c1 on s and c2 on other, as per Ericson section 5.1.9.
+// For parallel segments, one of the many closest pairs is returned.
+func (s Segment) ClosestPoints(other Segment) (c1, c2 Vector3) {
+	d1 := s.B.Sub(s.A)
+	d2 := other.B.Sub(other.A)
+	r := s.A.Sub(other.A)
+	a := d1.NormSq()
+	e := d2.NormSq()
+	f := d2.Dot(r)
+	var t1, t2 float64
+	switch {
+	case a == 0.0 && e == 0.0:
+		return s.A, other.A
+	case a == 0.0:
+		t2 = clamp01(f / e)
+	default:
+		c := d1.Dot(r)
+		if e == 0.0 {
+			t1 = clamp01(-c / a)
+		} else {
+			b := d1.Dot(d2)
+			denom := a*e - b*b
+			// For (nearly) parallel segments, pick t1 = 0 and let t2 follow.
+			if denom > parallelTol*a*e {
+				t1 = clamp01((b*f - c*e) / denom)
+			}
+			t2 = (b*t1 + f) / e
+			if t2 < 0.0 {
+				t2 = 0.0
+				t1 = clamp01(-c / a)
+			} else if t2 > 1.0 {
+				t2 = 1.0
+				t1 = clamp01((b - c) / a)
+			}
+		}
+	}
+	return s.At(t1), other.At(t2)
+}
+
+func (s Segment) SegmentDistance(other Segment) float64 {
+	c1, c2 := s.ClosestPoints(other)
+	return c1.Distance(c2)
+}
+
+// Parameter values, in increasing order, at which the segment crosses the
+// sphere's surface. There may be none, one (tangent, or one end inside)
+// or two intersections.
+func (s Segment) IntersectSphere(sp Sphere) []float64 {
+	d := s.B.Sub(s.A)
+	length := d.Norm()
+	if length == 0.0 {
+		return nil
+	}
+	u := d.Div(length)
+	f := s.A.Sub(sp.Center)
+	// Rather than the textbook quadratic, whose coefficient f.f - r^2 loses
+	// all precision for a small sphere far from A, work with the distance
+	// from the centre to the line, as per Haines et al. (2019) Precision
+	// improvements for ray/sphere intersection, Ray Tracing Gems, ch. 7.
+	along := -f.Dot(u)
+	perp := f.Add(u.Mul(along))
+	h2 := sp.Radius*sp.Radius - perp.NormSq()
+	if h2 < 0.0 {
+		return nil
+	}
+	h := math.Sqrt(h2)
+	roots := []float64{(along - h) / length, (along + h) / length}
+	var ts []float64
+	for i, t := range roots {
+		if t < 0.0 || t > 1.0 || (i > 0 && t == roots[0]) {
+			continue
+		}
+		ts = append(ts, t)
+	}
+	return ts
+}
+
+func clamp01(t float64) float64 {
+	return math.Min(1.0, math.Max(0.0, t))
+}
+
+//-----------------------------------------------------------------------------
+// Plane
+
+// Points x with Normal.x = D, where Normal is of unit length.
+type Plane struct {
+	Normal Vector3
+	D      float64
+}
+
+func NewPlane(point, normal Vector3) (Plane, error) {
+	if normal.Norm() == 0.0 {
+		return Plane{}, errors.New("Plane normal must be nonzero")
+	}
+	n := normal.Unit()
+	return Plane{Normal: n, D: n.Dot(point)}, nil
+}
+
+// The plane through three points, with normal (b-a)x(c-a).
+func NewPlaneFromPoints(a, b, c Vector3) (Plane, error) {
+	e1 := b.Sub(a)
+	e2 := c.Sub(a)
+	n := e1.Cross(e2)
+	if n.Norm() <= parallelTol*e1.Norm()*e2.Norm() {
+		return Plane{}, errors.New(fmt.Sprintf("Points %v %v %v are collinear", a, b, c))
+	}
+	return NewPlane(a, n)
+}
+
+// Positive on the side to which the normal points.
+func (pl Plane) SignedDistance(p Vector3) float64 {
+	return pl.Normal.Dot(p) - pl.D
+}
+
+func (pl Plane) Distance(p Vector3) float64 {
+	return math.Abs(pl.SignedDistance(p))
+}
+
+func (pl Plane) ClosestPoint(p Vector3) Vector3 {
+	return p.Sub(pl.Normal.Mul(pl.SignedDistance(p)))
+}
+
+//-----------------------------------------------------------------------------
+// Triangle
+
+type Triangle struct {
+	A, B, C Vector3
+}
+
+// Unit normal, in the direction of (B-A)x(C-A).
+func (tri Triangle) Normal() Vector3 {
+	return tri.B.Sub(tri.A).Cross(tri.C.Sub(tri.A)).Unit()
+}
+
+func (tri Triangle) Area() float64 {
+	return 0.5 * tri.B.Sub(tri.A).Cross(tri.C.Sub(tri.A)).Norm()
+}
+
+func (tri Triangle) Centroid() Vector3 {
+	return tri.A.Add(tri.B).Add(tri.C).Div(3.0)
+}
+
+// Closest point on the triangle to p, as per Ericson section 5.1.5,
+// working through the Voronoi regions of the vertices, edges and face.
+func (tri Triangle) ClosestPoint(p Vector3) Vector3 {
+	a, b, c := tri.A, tri.B, tri.C
+	ab := b.Sub(a)
+	ac := c.Sub(a)
+	ap := p.Sub(a)
+	d1 := ab.Dot(ap)
+	d2 := ac.Dot(ap)
+	if d1 <= 0.0 && d2 <= 0.0 {
+		return a
+	}
+	bp := p.Sub(b)
+	d3 := ab.Dot(bp)
+	d4 := ac.Dot(bp)
+	if d3 >= 0.0 && d4 <= d3 {
+		return b
+	}
+	vc := d1*d4 - d3*d2
+	if vc <= 0.0 && d1 >= 0.0 && d3 <= 0.0 {
+		return a.Add(ab.Mul(d1 / (d1 - d3)))
+	}
+	cp := p.Sub(c)
+	d5 := ab.Dot(cp)
+	d6 := ac.Dot(cp)
+	if d6 >= 0.0 && d5 <= d6 {
+		return c
+	}
+	vb := d5*d2 - d1*d6
+	if vb <= 0.0 && d2 >= 0.0 && d6 <= 0.0 {
+		return a.Add(ac.Mul(d2 / (d2 - d6)))
+	}
+	va := d3*d6 - d5*d4
+	if va <= 0.0 && (d4-d3) >= 0.0 && (d5-d6) >= 0.0 {
+		return b.Add(c.Sub(b).Mul((d4 - d3) / ((d4 - d3) + (d5 - d6))))
+	}
+	denom := va + vb + vc
+	if denom == 0.0 {
+		// Degenerate triangle; all of the edge tests failed only through round-off.
+		q, _ := Segment{a, b}.ClosestPoint(p)
+		return q
+	}
+	return a.Add(ab.Mul(vb / denom)).Add(ac.Mul(vc / denom))
+}
+
+func (tri Triangle) Distance(p Vector3) float64 {
+	return tri.ClosestPoint(p).Distance(p)
+}
+
+//-----------------------------------------------------------------------------
+// Sphere
+
+type Sphere struct {
+	Center Vector3
+	Radius float64
+}
+
+func (sp Sphere) Contains(p Vector3) bool {
+	return p.Sub(sp.Center).NormSq() <= sp.Radius*sp.Radius
+}
+
+// Distance to the surface, negative inside.
+func (sp Sphere) SignedDistance(p Vector3) float64 {
+	return p.Distance(sp.Center) - sp.Radius
+}
+
+// Closest point on the surface. For p at the centre, any surface point
+// is equally close and the one in the +x direction is returned.
+func (sp Sphere) ClosestPoint(p Vector3) Vector3 {
+	d := p.Sub(sp.Center)
+	if d.Norm() == 0.0 {
+		d = Vector3{1.0, 0.0, 0.0}
+	}
+	return sp.Center.Add(d.Unit().Mul(sp.Radius))
+}
+
+//-----------------------------------------------------------------------------
+// Axis-aligned bounding box
+
+type AABB struct {
+	Min, Max Vector3
+}
+
+// The smallest box containing all of the points.
+func NewAABB(points ...Vector3) (AABB, error) {
+	if len(points) == 0 {
+		return AABB{}, errors.New("Need at least one point for a bounding box")
+	}
+	box := AABB{points[0], points[0]}
+	for _, p := range points[1:] {
+		box = box.Expand(p)
+	}
+	return box, nil
+}
+
+func (box AABB) Expand(p Vector3) AABB {
+	return AABB{box.Min.Min(p), box.Max.Max(p)}
+}
+
+func (box AABB) Union(other AABB) AABB {
+	return AABB{box.Min.Min(other.Min), box.Max.Max(other.Max)}
+}
+
+func (box AABB) Center() Vector3 {
+	return box.Min.Lerp(box.Max, 0.5)
+}
+
+func (box AABB) Size() Vector3 {
+	return box.Max.Sub(box.Min)
+}
+
+func (box AABB) Contains(p Vector3) bool {
+	return p.X >= box.Min.X && p.X <= box.Max.X &&
+		p.Y >= box.Min.Y && p.Y <= box.Max.Y &&
+		p.Z >= box.Min.Z && p.Z <= box.Max.Z
+}
+
+func (box AABB) Intersects(other AABB) bool {
+	return box.Min.X <= other.Max.X && other.Min.X <= box.Max.X &&
+		box.Min.Y <= other.Max.Y && other.Min.Y <= box.Max.Y &&
+		box.Min.Z <= other.Max.Z && other.Min.Z <= box.Max.Z
+}
+
+// Closest point within the box; p itself if it is inside.
+func (box AABB) ClosestPoint(p Vector3) Vector3 {
+	return p.Max(box.Min).Min(box.Max)
+}
+
+// Zero for points inside the box.
+func (box AABB) Distance(p Vector3) float64 {
+	return box.ClosestPoint(p).Distance(p)
+}
@@ -0,0 +1,159 @@
+// primitives_test.go
+// Try out the intersection and closest-point functions of the primitives.
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRayIntersections(t *testing.T) {
+	pl, _ := NewPlane(Vector3{0.0, 0.0, 2.0}, Vector3{0.0, 0.0, 5.0})
+	r := Ray{Vector3{1.0, 1.0, 0.0}, Vector3{0.0, 0.0, 0.5}}
+	tp, ok := r.IntersectPlane(pl)
+	if !ok || math.Abs(tp-4.0) > 1.0e-12 || !r.At(tp).ApproxEquals(Vector3{1.0, 1.0, 2.0}, 1.0e-12) {
+		t.Errorf("Ray-plane intersection error t= %v ok= %v", tp, ok)
+	}
+	_, ok = Ray{r.Origin, r.Dir.Neg()}.IntersectPlane(pl)
+	if ok {
+		t.Errorf("Ray pointing away should miss the plane.")
+	}
+	// Nearly parallel, where the naive division gives t of about 1e17.
+	_, ok = Ray{r.Origin, Vector3{1.0, 0.0, 1.0e-17}}.IntersectPlane(pl)
+	if ok {
+		t.Errorf("Nearly parallel ray should miss the plane.")
+	}
+
+	tri := Triangle{Vector3{0.0, 0.0, 1.0}, Vector3{2.0, 0.0, 1.0}, Vector3{0.0, 2.0, 1.0}}
+	r2 := Ray{Vector3{0.5, 0.5, -1.0}, Vector3{0.0, 0.0, 1.0}}
+	tt, u, v, ok := r2.IntersectTriangle(tri)
+	if !ok || math.Abs(tt-2.0) > 1.0e-12 || math.Abs(u-0.25) > 1.0e-12 || math.Abs(v-0.25) > 1.0e-12 {
+		t.Errorf("Ray-triangle intersection error t= %v u= %v v= %v ok= %v", tt, u, v, ok)
+	}
+	_, _, _, ok = Ray{Vector3{1.5, 1.5, -1.0}, Vector3{0.0, 0.0, 1.0}}.IntersectTriangle(tri)
+	if ok {
+		t.Errorf("Ray outside triangle should miss.")
+	}
+	_, _, _, ok = Ray{Vector3{-1.0, 0.5, 1.0}, Vector3{1.0, 0.0, 1.0e-15}}.IntersectTriangle(tri)
+	if ok {
+		t.Errorf("Ray in the plane of the triangle should miss.")
+	}
+
+	box := AABB{Vector3{0.0, 0.0, 0.0}, Vector3{1.0, 2.0, 3.0}}
+	t0, t1, ok := Ray{Vector3{-1.0, 1.0, 1.0}, Vector3{1.0, 0.0, 0.0}}.IntersectAABB(box)
+	if !ok || t0 != 1.0 || t1 != 2.0 {
+		t.Errorf("Ray-box intersection error t0= %v t1= %v ok= %v", t0, t1, ok)
+	}
+	_, _, ok = Ray{Vector3{-1.0, 3.0, 1.0}, Vector3{1.0, 0.0, 0.0}}.IntersectAABB(box)
+	if ok {
+		t.Errorf("Ray beside the box should miss.")
+	}
+}
+
+func TestSegmentSphere(t *testing.T) {
+	sp := Sphere{Vector3{0.0, 0.0, 0.0}, 1.0}
+	s := Segment{Vector3{-2.0, 0.0, 0.0}, Vector3{2.0, 0.0, 0.0}}
+	ts := s.IntersectSphere(sp)
+	if len(ts) != 2 || math.Abs(ts[0]-0.25) > 1.0e-12 || math.Abs(ts[1]-0.75) > 1.0e-12 {
+		t.Errorf("Segment-sphere intersection error ts= %v", ts)
+	}
+	ts = Segment{Vector3{0.0, 0.0, 0.0}, Vector3{2.0, 0.0, 0.0}}.IntersectSphere(sp)
+	if len(ts) != 1 || math.Abs(ts[0]-0.5) > 1.0e-12 {
+		t.Errorf("Segment from inside error ts= %v", ts)
+	}
+	ts = Segment{Vector3{-2.0, 1.0, 0.0}, Vector3{2.0, 1.0, 0.0}}.IntersectSphere(sp)
+	if len(ts) != 1 || math.Abs(ts[0]-0.5) > 1.0e-12 {
+		t.Errorf("Tangent segment error ts= %v", ts)
+	}
+	ts = Segment{Vector3{-2.0, 1.5, 0.0}, Vector3{2.0, 1.5, 0.0}}.IntersectSphere(sp)
+	if len(ts) != 0 {
+		t.Errorf("Segment missing sphere error ts= %v", ts)
+	}
+	// A tiny sphere far along a long segment, where the textbook formula cancels.
+	small := Sphere{Vector3{1.0e8, 0.0, 0.0}, 1.0e-3}
+	ts = Segment{Vector3{0.0, 0.0, 0.0}, Vector3{2.0e8, 0.0, 0.0}}.IntersectSphere(small)
+	if len(ts) != 2 || math.Abs(ts[1]-ts[0]-1.0e-11) > 1.0e-15 {
+		t.Errorf("Small sphere intersection error ts= %v", ts)
+	}
+	if !sp.Contains(Vector3{0.5, 0.5, 0.5}) || math.Abs(sp.SignedDistance(Vector3{0.0, 3.0, 0.0})-2.0) > 1.0e-12 ||
+		!sp.ClosestPoint(Vector3{0.0, 3.0, 0.0}).ApproxEquals(Vector3{0.0, 1.0, 0.0}, 1.0e-12) {
+		t.Errorf("Sphere query error")
+	}
+}
+
+func TestClosestPoints(t *testing.T) {
+	s := Segment{Vector3{0.0, 0.0, 0.0}, Vector3{2.0, 0.0, 0.0}}
+	c, tc := s.ClosestPoint(Vector3{3.0, 1.0, 0.0})
+	if !c.ApproxEquals(s.B, 0.0) || tc != 1.0 || math.Abs(s.Distance(Vector3{1.0, 1.0, 0.0})-1.0) > 1.0e-12 {
+		t.Errorf("Segment closest point error c= %v t= %v", c, tc)
+	}
+	// Crossing segments.
+	s2 := Segment{Vector3{1.0, -1.0, 1.0}, Vector3{1.0, 1.0, 1.0}}
+	c1, c2 := s.ClosestPoints(s2)
+	if !c1.ApproxEquals(Vector3{1.0, 0.0, 0.0}, 1.0e-12) || !c2.ApproxEquals(Vector3{1.0, 0.0, 1.0}, 1.0e-12) {
+		t.Errorf("Segment-segment closest points error c1= %v c2= %v", c1, c2)
+	}
+	// Parallel, overlapping segments at distance 1.
+	s3 := Segment{Vector3{1.0, 1.0, 0.0}, Vector3{3.0, 1.0, 0.0}}
+	if math.Abs(s.SegmentDistance(s3)-1.0) > 1.0e-12 {
+		t.Errorf("Parallel segment distance error got= %v", s.SegmentDistance(s3))
+	}
+	s4 := Segment{Vector3{4.0, 1.0e-14, 0.0}, Vector3{6.0, 0.0, 0.0}}
+	if math.Abs(s.SegmentDistance(s4)-2.0) > 1.0e-12 {
+		t.Errorf("Nearly parallel segment distance error got= %v", s.SegmentDistance(s4))
+	}
+
+	r := Ray{Vector3{0.0, 0.0, 0.0}, Vector3{1.0, 0.0, 0.0}}
+	if math.Abs(r.Distance(Vector3{-3.0, 4.0, 0.0})-5.0) > 1.0e-12 || math.Abs(r.Distance(Vector3{3.0, 4.0, 0.0})-4.0) > 1.0e-12 {
+		t.Errorf("Ray distance error")
+	}
+
+	pl, err := NewPlaneFromPoints(Vector3{0.0, 0.0, 1.0}, Vector3{1.0, 0.0, 1.0}, Vector3{0.0, 1.0, 1.0})
+	if err != nil || math.Abs(pl.SignedDistance(Vector3{5.0, 5.0, 3.0})-2.0) > 1.0e-12 ||
+		!pl.ClosestPoint(Vector3{5.0, 5.0, 3.0}).ApproxEquals(Vector3{5.0, 5.0, 1.0}, 1.0e-12) {
+		t.Errorf("Plane query error pl= %v err= %v", pl, err)
+	}
+	_, err = NewPlaneFromPoints(Vector3{0.0, 0.0, 0.0}, Vector3{1.0, 1.0, 1.0}, Vector3{2.0, 2.0, 2.0})
+	if err == nil {
+		t.Errorf("Did not detect collinear points.")
+	}
+
+	tri := Triangle{Vector3{0.0, 0.0, 0.0}, Vector3{2.0, 0.0, 0.0}, Vector3{0.0, 2.0, 0.0}}
+	cases := [][2]Vector3{
+		{{0.5, 0.5, 3.0}, {0.5, 0.5, 0.0}},   // face
+		{{-1.0, -1.0, 0.0}, {0.0, 0.0, 0.0}}, // vertex A
+		{{3.0, -1.0, 0.0}, {2.0, 0.0, 0.0}},  // vertex B
+		{{-1.0, 3.0, 1.0}, {0.0, 2.0, 0.0}},  // vertex C
+		{{1.0, -1.0, 0.0}, {1.0, 0.0, 0.0}},  // edge AB
+		{{-1.0, 1.0, 0.0}, {0.0, 1.0, 0.0}},  // edge AC
+		{{2.0, 2.0, 0.0}, {1.0, 1.0, 0.0}},   // edge BC
+	}
+	for _, cs := range cases {
+		if !tri.ClosestPoint(cs[0]).ApproxEquals(cs[1], 1.0e-12) {
+			t.Errorf("Triangle closest point to %v got= %v want= %v", cs[0], tri.ClosestPoint(cs[0]), cs[1])
+		}
+	}
+	if tri.Area() != 2.0 || !tri.Normal().ApproxEquals(Vector3{0.0, 0.0, 1.0}, 1.0e-12) ||
+		math.Abs(tri.Distance(Vector3{0.5, 0.5, -3.0})-3.0) > 1.0e-12 {
+		t.Errorf("Triangle property error")
+	}
+
+	box, err := NewAABB(Vector3{1.0, 2.0, 3.0}, Vector3{-1.0, 0.0, 4.0}, Vector3{0.0, 5.0, 3.5})
+	if err != nil || box.Min != (Vector3{-1.0, 0.0, 3.0}) || box.Max != (Vector3{1.0, 5.0, 4.0}) {
+		t.Errorf("NewAABB error box= %v err= %v", box, err)
+	}
+	if !box.Contains(box.Center()) || box.Contains(Vector3{0.0, 0.0, 0.0}) ||
+		math.Abs(box.Distance(Vector3{0.0, 0.0, 0.0})-3.0) > 1.0e-12 || box.Size() != (Vector3{2.0, 5.0, 1.0}) {
+		t.Errorf("AABB query error")
+	}
+	if !box.Intersects(AABB{Vector3{1.0, 1.0, 1.0}, Vector3{2.0, 2.0, 3.0}}) ||
+		box.Intersects(AABB{Vector3{1.5, 1.0, 1.0}, Vector3{2.0, 2.0, 3.0}}) {
+		t.Errorf("AABB Intersects error")
+	}
+	_, err = NewAABB()
+	if err == nil {
+		t.Errorf("Did not detect empty point set.")
+	}
+}
@@ -0,0 +1,206 @@
+// quaternion.go
+// Quaternions for the orientation of rigid bodies.
+//
+// A unit quaternion q = (W, X, Y, Z) = (cos(a/2), sin(a/2).n) represents
+// the active rotation by angle a about the unit axis n, so that it
+// corresponds to the Matrix3 given by RotationAxisAngle(n, a).
+// The product p.Mul(q) is the rotation q followed by the rotation p,
+// matching the order of the Matrix3 product.
+//
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+type Quaternion struct {
+	W, X, Y, Z float64
+}
+
+func IdentityQuaternion() Quaternion {
+	return Quaternion{W: 1.0}
+}
+
+func (q Quaternion) String() string {
+	return fmt.Sprintf("(%0.6f, %0.6f, %0.6f, %0.6f)", q.W, q.X, q.Y, q.Z)
+}
+
+func (q Quaternion) ApproxEquals(other Quaternion, tol float64) bool {
+	return (math.Abs(q.W-other.W) <= tol) &&
+		(math.Abs(q.X-other.X) <= tol) &&
+		(math.Abs(q.Y-other.Y) <= tol) &&
+		(math.Abs(q.Z-other.Z) <= tol)
+}
+
+// True if q and other represent the same rotation, allowing for q and -q.
+func (q Quaternion) SameRotation(other Quaternion, tol float64) bool {
+	return q.ApproxEquals(other, tol) || q.ApproxEquals(other.Scale(-1.0), tol)
+}
+
+// The vector part, (X, Y, Z).
+func (q Quaternion) Vec() Vector3 {
+	return Vector3{q.X, q.Y, q.Z}
+}
+
+func (q Quaternion) Add(other Quaternion) Quaternion {
+	return Quaternion{q.W + other.W, q.X + other.X, q.Y + other.Y, q.Z + other.Z}
+}
+
+func (q Quaternion) Scale(s float64) Quaternion {
+	return Quaternion{s * q.W, s * q.X, s * q.Y, s * q.Z}
+}
+
+func (q Quaternion) Dot(other Quaternion) float64 {
+	return q.W*other.W + q.X*other.X + q.Y*other.Y + q.Z*other.Z
+}
+
+// Hamilton product q.other
+func (q Quaternion) Mul(other Quaternion) Quaternion {
+	return Quaternion{
+		q.W*other.W - q.X*other.X - q.Y*other.Y - q.Z*other.Z,
+		q.W*other.X + q.X*other.W + q.Y*other.Z - q.Z*other.Y,
+		q.W*other.Y - q.X*other.Z + q.Y*other.W + q.Z*other.X,
+		q.W*other.Z + q.X*other.Y - q.Y*other.X + q.Z*other.W,
+	}
+}
+
+func (q Quaternion) Conj() Quaternion {
+	return Quaternion{q.W, -q.X, -q.Y, -q.Z}
+}
+
+func (q Quaternion) Norm() float64 {
+	return math.Sqrt(q.Dot(q))
+}
+
+// A zero quaternion is returned unchanged, as for Vector3.Unit.
+func (q Quaternion) Normalize() Quaternion {
+	mag := q.Norm()
+	if mag == 0.0 {
+		return q
+	}
+	return q.Scale(1.0 / mag)
+}
+
+func (q Quaternion) Inverse() (Quaternion, error) {
+	d := q.Dot(q)
+	if d == 0.0 {
+		return Quaternion{}, errors.New("Zero quaternion has no inverse")
+	}
+	return q.Conj().Scale(1.0 / d), nil
+}
+
+// Rotate v by the unit quaternion q, that is, q.(0,v).q*
+func (q Quaternion) Rotate(v Vector3) Vector3 {
+	u := q.Vec()
+	t := u.Cross(v).Mul(2.0)
+	return v.Add(t.Mul(q.W)).Add(u.Cross(t))
+}
+
+// The rotation axis (of unit length) and angle in [0, pi] of the unit quaternion q.
+// The identity rotation gives the x-axis and a zero angle.
+func (q Quaternion) AxisAngle() (Vector3, float64) {
+	if q.W < 0.0 {
+		q = q.Scale(-1.0)
+	}
+	s := q.Vec().Norm()
+	if s == 0.0 {
+		return Vector3{1.0, 0.0, 0.0}, 0.0
+	}
+	return q.Vec().Div(s), 2.0 * math.Atan2(s, q.W)
+}
+
+// The rotation matrix for the unit quaternion q.
+func (q Quaternion) Matrix3() Matrix3 {
+	w, x, y, z := q.W, q.X, q.Y, q.Z
+	return Matrix3{
+		{1.0 - 2.0*(y*y+z*z), 2.0 * (x*y - w*z), 2.0 * (x*z + w*y)},
+		{2.0 * (x*y + w*z), 1.0 - 2.0*(x*x+z*z), 2.0 * (y*z - w*x)},
+		{2.0 * (x*z - w*y), 2.0 * (y*z + w*x), 1.0 - 2.0*(x*x+y*y)},
+	}
+}
+
+// The axis need not be of unit length but must be nonzero.
+func QuaternionFromAxisAngle(axis Vector3, angle float64) (Quaternion, error) {
+	if axis.Norm() == 0.0 {
+		return Quaternion{}, errors.New("Rotation axis must be nonzero")
+	}
+	s, c := math.Sincos(0.5 * angle)
+	n := axis.Unit().Mul(s)
+	return Quaternion{c, n.X, n.Y, n.Z}, nil
+}
+
+// Same composition of rotations as RotationEuler.
+func QuaternionFromEuler(a1, a2, a3 float64, conv EulerConvention) (Quaternion, error) {
+	seq, err := conv.axes()
+	if err != nil {
+		return Quaternion{}, err
+	}
+	axes := map[byte]Vector3{'X': {1.0, 0.0, 0.0}, 'Y': {0.0, 1.0, 0.0}, 'Z': {0.0, 0.0, 1.0}}
+	q1, _ := QuaternionFromAxisAngle(axes[seq[0]], a1)
+	q2, _ := QuaternionFromAxisAngle(axes[seq[1]], a2)
+	q3, _ := QuaternionFromAxisAngle(axes[seq[2]], a3)
+	if conv.Extrinsic {
+		return q3.Mul(q2).Mul(q1), nil
+	}
+	return q1.Mul(q2).Mul(q3), nil
+}
+
+// The unit quaternion for a rotation matrix, by Shepperd's method,
+// which works from the largest of the diagonal terms for accuracy.
+// The result has W >= 0.
+func QuaternionFromMatrix3(m Matrix3) Quaternion {
+	tr := m.Trace()
+	var q Quaternion
+	switch {
+	case tr >= m[0][0] && tr >= m[1][1] && tr >= m[2][2]:
+		s := 2.0 * math.Sqrt(1.0+tr)
+		q = Quaternion{0.25 * s, (m[2][1] - m[1][2]) / s, (m[0][2] - m[2][0]) / s, (m[1][0] - m[0][1]) / s}
+	case m[0][0] >= m[1][1] && m[0][0] >= m[2][2]:
+		s := 2.0 * math.Sqrt(1.0+m[0][0]-m[1][1]-m[2][2])
+		q = Quaternion{(m[2][1] - m[1][2]) / s, 0.25 * s, (m[0][1] + m[1][0]) / s, (m[0][2] + m[2][0]) / s}
+	case m[1][1] >= m[2][2]:
+		s := 2.0 * math.Sqrt(1.0+m[1][1]-m[0][0]-m[2][2])
+		q = Quaternion{(m[0][2] - m[2][0]) / s, (m[0][1] + m[1][0]) / s, 0.25 * s, (m[1][2] + m[2][1]) / s}
+	default:
+		s := 2.0 * math.Sqrt(1.0+m[2][2]-m[0][0]-m[1][1])
+		q = Quaternion{(m[1][0] - m[0][1]) / s, (m[0][2] + m[2][0]) / s, (m[1][2] + m[2][1]) / s, 0.25 * s}
+	}
+	if q.W < 0.0 {
+		q = q.Scale(-1.0)
+	}
+	return q.Normalize()
+}
+
+// Spherical linear interpolation between unit quaternions,
+// giving q at t=0 and other at t=1, along the shorter arc.
+func (q Quaternion) Slerp(other Quaternion, t float64) Quaternion {
+	d := q.Dot(other)
+	if d < 0.0 {
+		other = other.Scale(-1.0)
+		d = -d
+	}
+	if d > 0.9995 {
+		// Nearly the same rotation, where sin(theta) is too small
+		// to divide by, so fall back to normalized linear interpolation.
+		return q.Scale(1.0 - t).Add(other.Scale(t)).Normalize()
+	}
+	theta := math.Acos(d)
+	s := math.Sin(theta)
+	return q.Scale(math.Sin((1.0-t)*theta) / s).Add(other.Scale(math.Sin(t*theta) / s))
+}
+
+// Time derivative of the orientation q, for angular velocity omega
+// expressed in the body frame: dq/dt = q.(0,omega)/2.
+func (q Quaternion) Derivative(omega Vector3) Quaternion {
+	return q.Mul(Quaternion{0.0, omega.X, omega.Y, omega.Z}).Scale(0.5)
+}
+
+// Time derivative of the orientation q, for angular velocity omega
+// expressed in the fixed (world) frame: dq/dt = (0,omega).q/2.
+func (q Quaternion) DerivativeWorld(omega Vector3) Quaternion {
+	return Quaternion{0.0, omega.X, omega.Y, omega.Z}.Mul(q).Scale(0.5)
+}
@@ -0,0 +1,137 @@
+// quaternion_test.go
+// Try out the Quaternion functions, including integration of the
+// attitude kinematics with the rkf45 stepper.
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pajacobs-ghub/nm/rkf45"
+)
+
+func TestQuaternion(t *testing.T) {
+	x := Vector3{1.0, 0.0, 0.0}
+	y := Vector3{0.0, 1.0, 0.0}
+	axis := Vector3{1.0, 2.0, 2.0}
+	q, err := QuaternionFromAxisAngle(axis, 0.7)
+	if err != nil || math.Abs(q.Norm()-1.0) > 1.0e-12 {
+		t.Fatalf("QuaternionFromAxisAngle error q= %v err= %v", q, err)
+	}
+	r, _ := RotationAxisAngle(axis, 0.7)
+	if !q.Matrix3().ApproxEquals(r, 1.0e-12) {
+		t.Errorf("Quaternion Matrix3 error got= %v want= %v", q.Matrix3(), r)
+	}
+	v := Vector3{0.3, -1.2, 2.5}
+	if !q.Rotate(v).ApproxEquals(r.MulVec(v), 1.0e-12) {
+		t.Errorf("Quaternion Rotate error got= %v want= %v", q.Rotate(v), r.MulVec(v))
+	}
+	if !QuaternionFromMatrix3(r).SameRotation(q, 1.0e-12) {
+		t.Errorf("QuaternionFromMatrix3 error got= %v want= %v", QuaternionFromMatrix3(r), q)
+	}
+	// Each branch of Shepperd's method, including half-turns.
+	for _, a := range []Vector3{x, y, {0.0, 0.0, 1.0}, {1.0, 1.0, 0.0}} {
+		qa, _ := QuaternionFromAxisAngle(a, math.Pi)
+		ra, _ := RotationAxisAngle(a, math.Pi)
+		if !QuaternionFromMatrix3(ra).SameRotation(qa, 1.0e-12) {
+			t.Errorf("QuaternionFromMatrix3 half-turn about %v error", a)
+		}
+	}
+	n, angle := q.AxisAngle()
+	if !n.ApproxEquals(axis.Unit(), 1.0e-12) || math.Abs(angle-0.7) > 1.0e-12 {
+		t.Errorf("Quaternion AxisAngle error n= %v angle= %v", n, angle)
+	}
+
+	// Composition matches the matrix product.
+	p, _ := QuaternionFromAxisAngle(y, -0.4)
+	rp, _ := RotationAxisAngle(y, -0.4)
+	if !p.Mul(q).Matrix3().ApproxEquals(rp.Mul(r), 1.0e-12) {
+		t.Errorf("Quaternion Mul error")
+	}
+	qi, err := q.Inverse()
+	if err != nil || !q.Mul(qi).ApproxEquals(IdentityQuaternion(), 1.0e-12) || !qi.ApproxEquals(q.Conj(), 1.0e-12) {
+		t.Errorf("Quaternion Inverse error qi= %v", qi)
+	}
+	if !(Quaternion{2.0, 0.0, 0.0, 0.0}).Normalize().ApproxEquals(IdentityQuaternion(), 0.0) {
+		t.Errorf("Quaternion Normalize error")
+	}
+
+	qe, err := QuaternionFromEuler(0.3, -0.2, 0.1, EulerYawPitchRoll)
+	re, _ := RotationEuler(0.3, -0.2, 0.1, EulerYawPitchRoll)
+	if err != nil || !qe.Matrix3().ApproxEquals(re, 1.0e-12) {
+		t.Errorf("QuaternionFromEuler error qe= %v err= %v", qe, err)
+	}
+	_, err = QuaternionFromEuler(0.3, -0.2, 0.1, EulerConvention{Sequence: "XXY"})
+	if err == nil {
+		t.Errorf("Did not detect invalid Euler sequence.")
+	}
+}
+
+func TestSlerp(t *testing.T) {
+	z := Vector3{0.0, 0.0, 1.0}
+	q0 := IdentityQuaternion()
+	q1, _ := QuaternionFromAxisAngle(z, 2.0)
+	qh, _ := QuaternionFromAxisAngle(z, 0.5)
+	if !q0.Slerp(q1, 0.25).ApproxEquals(qh, 1.0e-12) {
+		t.Errorf("Slerp error got= %v want= %v", q0.Slerp(q1, 0.25), qh)
+	}
+	if !q0.Slerp(q1, 0.0).ApproxEquals(q0, 1.0e-12) || !q0.Slerp(q1, 1.0).ApproxEquals(q1, 1.0e-12) {
+		t.Errorf("Slerp end point error")
+	}
+	// The negated quaternion is the same rotation, so take the short way round.
+	if !q0.Slerp(q1.Scale(-1.0), 0.25).SameRotation(qh, 1.0e-12) {
+		t.Errorf("Slerp shortest-arc error got= %v", q0.Slerp(q1.Scale(-1.0), 0.25))
+	}
+	q2, _ := QuaternionFromAxisAngle(z, 1.0e-6)
+	qm := q0.Slerp(q2, 0.5)
+	qmref, _ := QuaternionFromAxisAngle(z, 0.5e-6)
+	if !qm.ApproxEquals(qmref, 1.0e-12) {
+		t.Errorf("Slerp nearly-equal error got= %v want= %v", qm, qmref)
+	}
+}
+
+func TestQuaternionKinematics(t *testing.T) {
+	// Constant body rate, for which q(t) = q0.exp(omega.t/2).
+	omega := Vector3{0.5, -1.0, 2.0}
+	q0, _ := QuaternionFromAxisAngle(Vector3{1.0, 1.0, 0.0}, 0.8)
+	f := func(t float64, y []float64, dydt []float64) {
+		qdot := Quaternion{y[0], y[1], y[2], y[3]}.Derivative(omega)
+		dydt[0], dydt[1], dydt[2], dydt[3] = qdot.W, qdot.X, qdot.Y, qdot.Z
+	}
+	y0 := []float64{q0.W, q0.X, q0.Y, q0.Z}
+	y1 := make([]float64, 4)
+	errs := make([]float64, 4)
+	work := rkf45.NewWorkSpace(4)
+	t0 := 0.0
+	nstep := 1000
+	h := 2.0 / float64(nstep)
+	for i := 0; i < nstep; i++ {
+		t0 = rkf45.Step(f, t0, h, y0, y1, errs, work)
+		copy(y0, y1)
+	}
+	q1 := Quaternion{y1[0], y1[1], y1[2], y1[3]}
+	dq, _ := QuaternionFromAxisAngle(omega, omega.Norm()*t0)
+	q1ref := q0.Mul(dq)
+	if !q1.ApproxEquals(q1ref, 1.0e-9) {
+		t.Errorf("Integrated body-rate attitude error got= %v want= %v", q1, q1ref)
+	}
+
+	// The same rate in the world frame rotates about the fixed axis instead.
+	fw := func(t float64, y []float64, dydt []float64) {
+		qdot := Quaternion{y[0], y[1], y[2], y[3]}.DerivativeWorld(omega)
+		dydt[0], dydt[1], dydt[2], dydt[3] = qdot.W, qdot.X, qdot.Y, qdot.Z
+	}
+	y0 = []float64{q0.W, q0.X, q0.Y, q0.Z}
+	t0 = 0.0
+	for i := 0; i < nstep; i++ {
+		t0 = rkf45.Step(fw, t0, h, y0, y1, errs, work)
+		copy(y0, y1)
+	}
+	q1 = Quaternion{y1[0], y1[1], y1[2], y1[3]}
+	q1ref = dq.Mul(q0)
+	if !q1.ApproxEquals(q1ref, 1.0e-9) {
+		t.Errorf("Integrated world-rate attitude error got= %v want= %v", q1, q1ref)
+	}
+}
@@ -0,0 +1,97 @@
+// surface.go
+// Parametric surfaces, p(r, s) for 0 <= r, s <= 1.
+//
+// As for paths, a Surface need only be evaluated and SurfaceDerivs uses
+// analytic derivatives from a DifferentiableSurface when available.
+//
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+type Surface interface {
+	Eval(r, s float64) Vector3
+}
+
+type DifferentiableSurface interface {
+	Surface
+	Derivs(r, s float64) (dpdr, dpds Vector3)
+}
+
+// Partial derivatives at (r, s), using one-sided differences at the edges.
+func SurfaceDerivs(surf Surface, r, s float64) (dpdr, dpds Vector3) {
+	if ds, ok := surf.(DifferentiableSurface); ok {
+		return ds.Derivs(r, s)
+	}
+	r0, r1 := math.Max(0.0, r-derivStep), math.Min(1.0, r+derivStep)
+	s0, s1 := math.Max(0.0, s-derivStep), math.Min(1.0, s+derivStep)
+	dpdr = surf.Eval(r1, s).Sub(surf.Eval(r0, s)).Div(r1 - r0)
+	dpds = surf.Eval(r, s1).Sub(surf.Eval(r, s0)).Div(s1 - s0)
+	return dpdr, dpds
+}
+
+// Unit normal, in the direction of dp/dr x dp/ds.
+func SurfaceNormal(surf Surface, r, s float64) Vector3 {
+	dpdr, dpds := SurfaceDerivs(surf, r, s)
+	return dpdr.Cross(dpds).Unit()
+}
+
+//-----------------------------------------------------------------------------
+// Coons patch
+
+// Bilinearly-blended surface bounded by four paths, with
+// South p(r,0), North p(r,1), West p(0,s) and East p(1,s).
+type CoonsPatch struct {
+	North, East, South, West Path
+	p00, p10, p01, p11       Vector3
+}
+
+// The ends of the paths must meet at the corners.
+func NewCoonsPatch(north, east, south, west Path) (*CoonsPatch, error) {
+	c := CoonsPatch{North: north, East: east, South: south, West: west}
+	c.p00 = south.Eval(0.0)
+	c.p10 = south.Eval(1.0)
+	c.p01 = north.Eval(0.0)
+	c.p11 = north.Eval(1.0)
+	size := c.p00.Distance(c.p11) + c.p10.Distance(c.p01)
+	tol := 1.0e-9 * (1.0 + size)
+	corners := []struct {
+		name string
+		a, b Vector3
+	}{
+		{"south-west", c.p00, west.Eval(0.0)},
+		{"south-east", c.p10, east.Eval(0.0)},
+		{"north-west", c.p01, west.Eval(1.0)},
+		{"north-east", c.p11, east.Eval(1.0)},
+	}
+	for _, cn := range corners {
+		if cn.a.Distance(cn.b) > tol {
+			msg := fmt.Sprintf("Paths do not meet at the %s corner: %v and %v", cn.name, cn.a, cn.b)
+			return nil, errors.New(msg)
+		}
+	}
+	return &c, nil
+}
+
+func (c *CoonsPatch) Eval(r, s float64) Vector3 {
+	p := c.South.Eval(r).Mul(1.0 - s).Add(c.North.Eval(r).Mul(s)).
+		Add(c.West.Eval(s).Mul(1.0 - r)).Add(c.East.Eval(s).Mul(r))
+	bilinear := c.p00.Mul((1.0 - r) * (1.0 - s)).Add(c.p10.Mul(r * (1.0 - s))).
+		Add(c.p01.Mul((1.0 - r) * s)).Add(c.p11.Mul(r * s))
+	return p.Sub(bilinear)
+}
+
+func (c *CoonsPatch) Derivs(r, s float64) (dpdr, dpds Vector3) {
+	dpdr = PathDeriv(c.South, r).Mul(1.0 - s).Add(PathDeriv(c.North, r).Mul(s)).
+		Sub(c.West.Eval(s)).Add(c.East.Eval(s)).
+		Sub(c.p10.Sub(c.p00).Mul(1.0 - s)).Sub(c.p11.Sub(c.p01).Mul(s))
+	dpds = c.North.Eval(r).Sub(c.South.Eval(r)).
+		Add(PathDeriv(c.West, s).Mul(1.0 - r)).Add(PathDeriv(c.East, s).Mul(r)).
+		Sub(c.p01.Sub(c.p00).Mul(1.0 - r)).Sub(c.p11.Sub(c.p10).Mul(r))
+	return dpdr, dpds
+}
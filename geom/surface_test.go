@@ -0,0 +1,51 @@
+// surface_test.go
+// Try out the Coons patch.
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCoonsPatch(t *testing.T) {
+	p00, p10 := Vector3{0.0, 0.0, 0.0}, Vector3{2.0, 0.0, 0.0}
+	p01, p11 := Vector3{0.0, 1.0, 0.0}, Vector3{2.0, 1.5, 0.5}
+	// With straight edges, the patch is the bilinear surface.
+	c, err := NewCoonsPatch(Line{p01, p11}, Line{p10, p11}, Line{p00, p10}, Line{p00, p01})
+	if err != nil {
+		t.Fatalf("NewCoonsPatch failed, err: %s", err)
+	}
+	r, s := 0.3, 0.8
+	want := p00.Mul((1 - r) * (1 - s)).Add(p10.Mul(r * (1 - s))).Add(p01.Mul((1 - r) * s)).Add(p11.Mul(r * s))
+	if !c.Eval(r, s).ApproxEquals(want, 1.0e-12) {
+		t.Errorf("Bilinear Coons patch error got= %v want= %v", c.Eval(r, s), want)
+	}
+
+	// A curved north boundary is reproduced along s=1.
+	north, _ := NewBezier([]Vector3{p01, {1.0, 2.5, 0.0}, p11})
+	c, err = NewCoonsPatch(north, Line{p10, p11}, Line{p00, p10}, Line{p00, p01})
+	if err != nil {
+		t.Fatalf("NewCoonsPatch with curved edge failed, err: %s", err)
+	}
+	for _, rr := range []float64{0.0, 0.25, 0.6, 1.0} {
+		if !c.Eval(rr, 1.0).ApproxEquals(north.Eval(rr), 1.0e-12) || !c.Eval(rr, 0.0).ApproxEquals(p00.Lerp(p10, rr), 1.0e-12) {
+			t.Errorf("Coons patch boundary error at r= %v", rr)
+		}
+	}
+	dr, ds := c.Derivs(r, s)
+	fdr, fds := SurfaceDerivs(struct{ Surface }{c}, r, s)
+	if !dr.ApproxEquals(fdr, 1.0e-6) || !ds.ApproxEquals(fds, 1.0e-6) {
+		t.Errorf("Coons patch derivative error dr= %v fdr= %v ds= %v fds= %v", dr, fdr, ds, fds)
+	}
+	n := SurfaceNormal(c, 0.5, 0.5)
+	if math.Abs(n.Norm()-1.0) > 1.0e-12 || n.Z <= 0.0 {
+		t.Errorf("Coons patch normal error n= %v", n)
+	}
+
+	_, err = NewCoonsPatch(north, Line{p10, p11.Add(Vector3{0.1, 0.0, 0.0})}, Line{p00, p10}, Line{p00, p01})
+	if err == nil {
+		t.Errorf("Did not detect mismatched corner.")
+	}
+}
@@ -43,3 +43,88 @@ func (v Vector3) Dot(other Vector3) float64 {
 func (v *Vector3) Dotp(other *Vector3) float64 {
 	return v.X*other.X + v.Y*other.Y + v.Z*other.Z
 }
+
+func (v Vector3) Div(d float64) Vector3 {
+	return Vector3{v.X / d, v.Y / d, v.Z / d}
+}
+
+func (v Vector3) Neg() Vector3 {
+	return Vector3{-v.X, -v.Y, -v.Z}
+}
+
+func (v Vector3) Cross(other Vector3) Vector3 {
+	return Vector3{
+		v.Y*other.Z - v.Z*other.Y,
+		v.Z*other.X - v.X*other.Z,
+		v.X*other.Y - v.Y*other.X,
+	}
+}
+
+// Euclidian (L2) norm
+func (v Vector3) Norm() float64 {
+	return math.Sqrt(v.X*v.X + v.Y*v.Y + v.Z*v.Z)
+}
+
+// Same as Norm, for readability in geometric code.
+func (v Vector3) Length() float64 {
+	return v.Norm()
+}
+
+func (v Vector3) NormSq() float64 {
+	return v.X*v.X + v.Y*v.Y + v.Z*v.Z
+}
+
+// A zero vector is returned unchanged, as for array.Vector.Normalize.
+func (v Vector3) Unit() Vector3 {
+	mag := v.Norm()
+	if mag == 0.0 {
+		return v
+	}
+	return v.Div(mag)
+}
+
+func (v Vector3) Distance(other Vector3) float64 {
+	return v.Sub(other).Norm()
+}
+
+// Angle in radians, in the range [0, pi].
+// The atan2 form keeps full accuracy for nearly parallel vectors,
+// where acos of the normalized dot product does not.
+// The angle is zero if either vector is zero.
+func (v Vector3) Angle(other Vector3) float64 {
+	return math.Atan2(v.Cross(other).Norm(), v.Dot(other))
+}
+
+// The component of v along the direction of other.
+// Projection onto a zero vector gives a zero vector.
+func (v Vector3) Project(other Vector3) Vector3 {
+	d := other.NormSq()
+	if d == 0.0 {
+		return Vector3{}
+	}
+	return other.Mul(v.Dot(other) / d)
+}
+
+// The component of v perpendicular to other, v - v.Project(other).
+func (v Vector3) Reject(other Vector3) Vector3 {
+	return v.Sub(v.Project(other))
+}
+
+// Component-wise minimum.
+func (v Vector3) Min(other Vector3) Vector3 {
+	return Vector3{math.Min(v.X, other.X), math.Min(v.Y, other.Y), math.Min(v.Z, other.Z)}
+}
+
+// Component-wise maximum.
+func (v Vector3) Max(other Vector3) Vector3 {
+	return Vector3{math.Max(v.X, other.X), math.Max(v.Y, other.Y), math.Max(v.Z, other.Z)}
+}
+
+// Linear interpolation, giving v at t=0 and other at t=1.
+func (v Vector3) Lerp(other Vector3, t float64) Vector3 {
+	return Vector3{
+		v.X + t*(other.X-v.X),
+		v.Y + t*(other.Y-v.Y),
+		v.Z + t*(other.Z-v.Z),
+	}
+}
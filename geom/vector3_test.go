@@ -6,6 +6,7 @@
 package geom
 
 import (
+	"math"
 	"testing"
 )
 
@@ -18,3 +19,51 @@ func TestVector3(t *testing.T) {
 		t.Errorf("Vector3 Add error v1= %v want= %v", v1, v3)
 	}
 }
+
+func TestVector3Algebra(t *testing.T) {
+	x := Vector3{1.0, 0.0, 0.0}
+	y := Vector3{0.0, 1.0, 0.0}
+	z := Vector3{0.0, 0.0, 1.0}
+	if !x.Cross(y).ApproxEquals(z, 1.0e-12) || !y.Cross(x).ApproxEquals(z.Neg(), 1.0e-12) {
+		t.Errorf("Vector3 Cross error x.y= %v want= %v", x.Cross(y), z)
+	}
+	v := Vector3{3.0, 4.0, 12.0}
+	if math.Abs(v.Norm()-13.0) > 1.0e-12 || v.Length() != v.Norm() || v.NormSq() != 169.0 {
+		t.Errorf("Vector3 Norm error got= %v want= 13.0", v.Norm())
+	}
+	if math.Abs(v.Unit().Norm()-1.0) > 1.0e-12 || (Vector3{}).Unit() != (Vector3{}) {
+		t.Errorf("Vector3 Unit error got= %v", v.Unit())
+	}
+	if math.Abs(v.Distance(Vector3{3.0, 0.0, 9.0})-5.0) > 1.0e-12 {
+		t.Errorf("Vector3 Distance error got= %v want= 5.0", v.Distance(Vector3{3.0, 0.0, 9.0}))
+	}
+	if math.Abs(x.Angle(Vector3{1.0, 1.0, 0.0})-math.Pi/4) > 1.0e-12 ||
+		math.Abs(x.Angle(x.Neg())-math.Pi) > 1.0e-12 {
+		t.Errorf("Vector3 Angle error got= %v want= pi/4", x.Angle(Vector3{1.0, 1.0, 0.0}))
+	}
+	// Nearly parallel vectors, where acos would lose all precision.
+	a := x.Angle(Vector3{1.0, 1.0e-9, 0.0})
+	if math.Abs(a-1.0e-9) > 1.0e-20 {
+		t.Errorf("Vector3 small Angle error got= %v want= 1e-9", a)
+	}
+	p := v.Project(x)
+	r := v.Reject(x)
+	if !p.ApproxEquals(Vector3{3.0, 0.0, 0.0}, 1.0e-12) || math.Abs(r.Dot(x)) > 1.0e-12 ||
+		!p.Add(r).ApproxEquals(v, 1.0e-12) {
+		t.Errorf("Vector3 Project/Reject error p= %v r= %v", p, r)
+	}
+	if v.Project(Vector3{}) != (Vector3{}) {
+		t.Errorf("Vector3 Project onto zero should give zero.")
+	}
+	w := Vector3{-1.0, 5.0, 12.0}
+	if v.Min(w) != (Vector3{-1.0, 4.0, 12.0}) || v.Max(w) != (Vector3{3.0, 5.0, 12.0}) {
+		t.Errorf("Vector3 Min/Max error min= %v max= %v", v.Min(w), v.Max(w))
+	}
+	if !v.Lerp(w, 0.25).ApproxEquals(Vector3{2.0, 4.25, 12.0}, 1.0e-12) ||
+		v.Lerp(w, 0.0) != v || v.Lerp(w, 1.0) != w {
+		t.Errorf("Vector3 Lerp error got= %v", v.Lerp(w, 0.25))
+	}
+	if v.Div(2.0) != v.Mul(0.5) {
+		t.Errorf("Vector3 Div error got= %v", v.Div(2.0))
+	}
+}
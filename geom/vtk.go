@@ -0,0 +1,65 @@
+// vtk.go
+// Writing structured grids in the VTK formats for viewing in ParaView.
+//
+// Legacy: the ASCII .vtk format, DATASET STRUCTURED_GRID.
+// XML:    the .vts StructuredGrid format, with ASCII data arrays.
+//
+// PJ 2026-10-18
+
+package geom
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+func formatPoint(b *bufio.Writer, p Vector3) {
+	b.WriteString(strconv.FormatFloat(p.X, 'g', -1, 64))
+	b.WriteString(" ")
+	b.WriteString(strconv.FormatFloat(p.Y, 'g', -1, 64))
+	b.WriteString(" ")
+	b.WriteString(strconv.FormatFloat(p.Z, 'g', -1, 64))
+	b.WriteString("\n")
+}
+
+// The title is limited to a single line of 256 characters.
+func (g *StructuredGrid) WriteVTK(w io.Writer, title string) error {
+	title = strings.ReplaceAll(strings.ReplaceAll(title, "\r", " "), "\n", " ")
+	if len(title) > 256 {
+		title = title[:256]
+	}
+	b := bufio.NewWriter(w)
+	b.WriteString("# vtk DataFile Version 2.0\n")
+	b.WriteString(title + "\n")
+	b.WriteString("ASCII\n")
+	b.WriteString("DATASET STRUCTURED_GRID\n")
+	b.WriteString(fmt.Sprintf("DIMENSIONS %d %d %d\n", g.NI, g.NJ, g.NK))
+	b.WriteString(fmt.Sprintf("POINTS %d double\n", len(g.Vertices)))
+	for _, p := range g.Vertices {
+		formatPoint(b, p)
+	}
+	return b.Flush()
+}
+
+func (g *StructuredGrid) WriteVTS(w io.Writer) error {
+	extent := fmt.Sprintf("0 %d 0 %d 0 %d", g.NI-1, g.NJ-1, g.NK-1)
+	b := bufio.NewWriter(w)
+	b.WriteString("<?xml version=\"1.0\"?>\n")
+	b.WriteString("<VTKFile type=\"StructuredGrid\" version=\"0.1\" byte_order=\"LittleEndian\">\n")
+	b.WriteString(fmt.Sprintf("<StructuredGrid WholeExtent=\"%s\">\n", extent))
+	b.WriteString(fmt.Sprintf("<Piece Extent=\"%s\">\n", extent))
+	b.WriteString("<Points>\n")
+	b.WriteString("<DataArray type=\"Float64\" NumberOfComponents=\"3\" format=\"ascii\">\n")
+	for _, p := range g.Vertices {
+		formatPoint(b, p)
+	}
+	b.WriteString("</DataArray>\n")
+	b.WriteString("</Points>\n")
+	b.WriteString("</Piece>\n")
+	b.WriteString("</StructuredGrid>\n")
+	b.WriteString("</VTKFile>\n")
+	return b.Flush()
+}